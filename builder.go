@@ -26,6 +26,10 @@ import (
 	"fmt"
 	"maps"
 	"net/http"
+	"net/textproto"
+	"reflect"
+	"slices"
+	"time"
 
 	"github.com/jay-babu/go-problem/internal/stack"
 	"github.com/neocotic/go-optional"
@@ -76,23 +80,42 @@ type Builder struct {
 	// def is the Definition whose fields are to be treated as defaults when a field is not explicitly defined. See
 	// Builder.Definition and Builder.DefinitionType for more information.
 	def Definition
+	// defined is whether def was explicitly provided using Builder.Definition, rather than being derived from a Type
+	// provided using Builder.DefinitionType, and so is to be linked to the Problem being built.
+	defined bool
 	// detail is the explicitly defined detail to be used. See Builder.Detail for more information.
 	detail string
+	// detailFlag contains the detail flags to be used. See Builder.Detail for more information.
+	detailFlag optional.Optional[Flag]
 	// detailKey is the explicitly defined translation key to be used to resolve a localized detail. See
 	// Builder.DetailKey for more information.
 	detailKey any
-	// err is the explicitly defined error to be wrapped. See Builder.Wrap for more information.
-	err error
+	// errs contains the explicitly defined errors to be wrapped. See Builder.Wrap and Builder.WrapAll for more
+	// information.
+	errs []error
 	// extensions is a shallow clone of the explicitly defined extensions to be used. See Builder.Extension and
 	// Builder.Extensions for more information.
 	extensions map[string]any
+	// header contains the explicitly defined HTTP response headers to be used. See Builder.Header and Builder.Headers
+	// for more information.
+	header http.Header
 	// instanceURI is the explicitly defined instance URI reference to be used. See Builder.Instance for more
 	// information.
 	instanceURI string
+	// logExtensions is a shallow clone of the explicitly defined extensions to be used that are only visible in logs.
+	// See Builder.ExtensionLogOnly for more information.
+	logExtensions map[string]any
 	// logLevel is the explicitly defined LogLevel to be used. See Builder.LogLevel for more information.
 	logLevel LogLevel
 	// problem contains any fields unwrapped from err using an Unwrapper. See Builder.Wrap for more information.
 	problem Problem
+	// retryAfter is the explicitly defined retry delay to be used. See Builder.RetryAfter for more information.
+	retryAfter time.Duration
+	// retryAt is the explicitly defined point in time after which a retry may be made. See Builder.RetryAt for more
+	// information.
+	retryAt time.Time
+	// retryable is whether the Problem is explicitly defined as retryable. See Builder.Retryable for more information.
+	retryable optional.Optional[bool]
 	// stack is the captured stack trace to be used. See Builder.Stack for more information.
 	//
 	// stack is captured lazily and priority is given to any existing stack contained within problem. getStack must be
@@ -119,6 +142,9 @@ type Builder struct {
 	uuid string
 	// uuidFlag contains the "UUID" flags to be used. See Builder.UUID for more information.
 	uuidFlag optional.Optional[Flag]
+	// validationErrs contains the explicitly defined validation errors to be used. See Builder.ValidationErrors for more
+	// information.
+	validationErrs []FieldError
 }
 
 var _ fmt.Stringer = (*Builder)(nil)
@@ -138,6 +164,9 @@ func (b *Builder) Clone() *Builder {
 	clone := *b
 	// Shallow clone will have to do since extensions could contain any type of values
 	clone.extensions = maps.Clone(b.extensions)
+	clone.header = b.header.Clone()
+	clone.logExtensions = maps.Clone(b.logExtensions)
+	clone.validationErrs = slices.Clone(b.validationErrs)
 	return &clone
 }
 
@@ -161,8 +190,11 @@ func (b *Builder) Code(value uint, namespace CodeNamespace) *Builder {
 //
 // The fields of def are treated as defaults when a field is not explicitly defined. This method can conflict with
 // Builder.DefinitionType as it effectively assigns to the same underlying field.
+//
+// The Problem built is linked to def (see Problem.Definition), unless Builder.DefinitionType is called afterwards.
 func (b *Builder) Definition(def Definition) *Builder {
 	b.def = def
+	b.defined = true
 	return b
 }
 
@@ -170,18 +202,30 @@ func (b *Builder) Definition(def Definition) *Builder {
 //
 // The fields of defType are treated as defaults when a field is not explicitly defined. This method can conflict with
 // Builder.Definition as it effectively assigns to the same underlying field, however, only setting Definition.Type.
+//
+// Since the resulting Definition is not one that was explicitly provided, the Problem built is not linked to it (see
+// Problem.Definition).
 func (b *Builder) DefinitionType(defType Type) *Builder {
 	b.def.Type = defType
+	b.defined = false
 	return b
 }
 
-// Detail sets the given detail to be used when building a Problem. See Problem.Detail for more information.
+// Detail sets the given detail to be used when building a Problem, optionally along with flags to be used to control
+// where the detail is visible. See Problem.Detail for more information.
 //
 // If detail is not empty, it will take precedence over anything provided using Builder.Definition or Builder.Wrap.
 // However, if a localized detail is resolved from a translation key using Builder.DetailKey, that will take precedence
 // over detail.
-func (b *Builder) Detail(detail string) *Builder {
+//
+// If no flags are provided, this is considered equal to passing FlagField and FlagLog. Passing only FlagLog results in
+// the detail being visible any time the Problem is logged (see LogInfo.Detail) without ever being present on
+// Problem.Detail, which is useful for details that are not safe to be exposed to clients. If FlagDisable is given, all
+// other flags are ignored and the detail is not visible at all. The flags apply to whichever detail is resolved,
+// including one derived from a translation key, Builder.Definition, or Builder.Wrap.
+func (b *Builder) Detail(detail string, flags ...Flag) *Builder {
 	b.detail = detail
+	b.detailFlag = resolveFlag(flags)
 	return b
 }
 
@@ -223,6 +267,28 @@ func (b *Builder) Extension(key string, value any) *Builder {
 		panic(err)
 	}
 	b.extensions[key] = value
+	delete(b.logExtensions, key)
+	return b
+}
+
+// ExtensionLogOnly appends the given extension key and value to that used when building a Problem, but only to be
+// visible any time the Problem is logged (see LogInfo.Extensions) without ever being present on Problem.Extensions.
+//
+// This is useful for diagnostic information that is not safe to be exposed to clients (e.g. internal identifiers or
+// upstream responses). If the key overlaps with any extension provided using Builder.Extension or Builder.Extensions,
+// that extension is deleted. Likewise, any subsequent use of Builder.Extension or Builder.Extensions with an
+// overlapping key will delete the log-only extension.
+//
+// Panics if key is either empty or reserved (i.e. conflicts with Problem-level fields).
+func (b *Builder) ExtensionLogOnly(key string, value any) *Builder {
+	if b.logExtensions == nil {
+		b.logExtensions = make(map[string]any)
+	}
+	if err := validationExtensionKey(key); err != nil {
+		panic(err)
+	}
+	b.logExtensions[key] = value
+	delete(b.extensions, key)
 	return b
 }
 
@@ -250,10 +316,83 @@ func (b *Builder) Extensions(extensions Extensions) *Builder {
 			panic(err)
 		}
 		b.extensions[k] = v
+		delete(b.logExtensions, k)
+	}
+	return b
+}
+
+// ExtensionsFrom sets extensions derived from the given struct to be used when building a Problem. See
+// Problem.Extensions for more information.
+//
+// Each exported field within v is flattened into an extension, named using its "json" tag in the same way as
+// encoding/json (i.e. fields tagged with "-" are ignored, "omitempty" and "omitzero" are honored, and fields of embedded
+// structs are promoted), while retaining the original field value. v may also be a pointer to a struct or a map with
+// string keys. For example;
+//
+//	type RateLimit struct {
+//		Limit     int       `json:"limit"`
+//		Remaining int       `json:"remaining"`
+//		Reset     time.Time `json:"reset"`
+//	}
+//
+//	prob := problem.Build().
+//		Status(http.StatusTooManyRequests).
+//		ExtensionsFrom(RateLimit{Limit: 100, Reset: reset}).
+//		Problem()
+//
+// Like Builder.Extensions, any derived extensions will take precedence over anything provided using Builder.Definition
+// or Builder.Wrap and does not delete/modify any other extensions unless the key overlaps.
+//
+// Panics if v is of an unsupported type or results in a key that is either empty or reserved (i.e. conflicts with
+// Problem-level fields).
+func (b *Builder) ExtensionsFrom(v any) *Builder {
+	extensions, err := extensionsFrom(v)
+	if err != nil {
+		panic(err)
+	}
+	if len(extensions) == 0 {
+		return b
+	}
+	return b.Extensions(extensions)
+}
+
+// Header appends the given values to those of the HTTP response header with the given key to be used when building a
+// Problem. See Problem.Header for more information.
+//
+// key is canonicalized in the same way as http.Header.Add. When used, the values of key will take precedence over
+// those provided using Builder.Definition or Builder.Wrap.
+//
+// Builder.Headers may be preferred for providing multiple headers and does not conflict with usage of Header in that
+// neither method will delete/modify headers unless the key overlaps.
+func (b *Builder) Header(key string, values ...string) *Builder {
+	if b.header == nil {
+		b.header = make(http.Header)
+	}
+	for _, v := range values {
+		b.header.Add(key, v)
 	}
 	return b
 }
 
+// Headers sets a clone of the values of each of the given HTTP response headers to be used when building a Problem. See
+// Problem.Header for more information.
+//
+// Each key is canonicalized in the same way as http.Header.Set. When used, the values of each key will take precedence
+// over those provided using Builder.Definition or Builder.Wrap.
+//
+// Builder.Header may be preferred for providing a single header and does not conflict with usage of Headers in that
+// neither method will delete/modify headers unless the key overlaps, in which case the values will be overwritten.
+func (b *Builder) Headers(header http.Header) *Builder {
+	if len(header) == 0 {
+		return b
+	}
+	if b.header == nil {
+		b.header = make(http.Header, len(header))
+	}
+	mergeHeader(b.header, header)
+	return b
+}
+
 // Instance sets the instance URI reference to be used when building a Problem. See Problem.Instance for more
 // information.
 //
@@ -294,13 +433,20 @@ func (b *Builder) Reset() *Builder {
 	// Retain Generator and ctx
 	b.code = ""
 	b.def = Definition{}
+	b.defined = false
 	b.detail = ""
+	b.detailFlag = optional.Empty[Flag]()
 	b.detailKey = nil
-	b.err = nil
+	b.errs = nil
 	b.extensions = nil
+	b.header = nil
 	b.instanceURI = ""
+	b.logExtensions = nil
 	b.logLevel = 0
 	b.problem = Problem{}
+	b.retryAfter = 0
+	b.retryAt = time.Time{}
+	b.retryable = optional.Empty[bool]()
 	b.stack = ""
 	b.stackFlag = optional.Empty[Flag]()
 	b.stackFramesSkipped = 0
@@ -310,6 +456,45 @@ func (b *Builder) Reset() *Builder {
 	b.typeURI = ""
 	b.uuid = ""
 	b.uuidFlag = optional.Empty[Flag]()
+	b.validationErrs = nil
+	return b
+}
+
+// RetryAfter sets the delay after which the request that resulted in the Problem may be retried when building a
+// Problem. See Problem.RetryAfter for more information.
+//
+// When the Problem is written to an HTTP response, the delay is included in the Retry-After header as a number of
+// seconds, rounded up. This method conflicts with Builder.RetryAt as only the last one used is applied.
+//
+// If delay is greater than zero, it will take precedence over anything provided using Builder.Definition,
+// Builder.DefinitionType, or Builder.Wrap.
+func (b *Builder) RetryAfter(delay time.Duration) *Builder {
+	b.retryAfter = delay
+	b.retryAt = time.Time{}
+	return b
+}
+
+// RetryAt sets the point in time after which the request that resulted in the Problem may be retried when building a
+// Problem. See Problem.RetryAfter for more information.
+//
+// When the Problem is written to an HTTP response, the point in time is included in the Retry-After header as an
+// HTTP-date. This method conflicts with Builder.RetryAfter as only the last one used is applied.
+//
+// If t is not zero, it will take precedence over anything provided using Builder.Definition, Builder.DefinitionType,
+// or Builder.Wrap.
+func (b *Builder) RetryAt(t time.Time) *Builder {
+	b.retryAfter = 0
+	b.retryAt = t
+	return b
+}
+
+// Retryable sets whether the request that resulted in the Problem may be retried when building a Problem. See
+// Problem.Retryable for more information.
+//
+// When used, it will take precedence over anything provided using Builder.Definition, Builder.DefinitionType, or
+// Builder.Wrap, as well as any retry delay.
+func (b *Builder) Retryable(retryable bool) *Builder {
+	b.retryable = optional.Of(retryable)
 	return b
 }
 
@@ -419,6 +604,19 @@ func (b *Builder) UUID(flags ...Flag) *Builder {
 	return b
 }
 
+// ValidationErrors appends the FieldError within the given ValidationErrors to those used when building a Problem. See
+// ValidationErrors for more information.
+//
+// When used, the FieldError are included within an extension with the ValidationErrorsExtensionKey, which will take
+// precedence over any extension with the same key, with the detail of each FieldError being localized from its
+// translation key using Generator.Translator, where possible.
+func (b *Builder) ValidationErrors(errs *ValidationErrors) *Builder {
+	if n := errs.Len(); n > 0 {
+		b.validationErrs = append(b.validationErrs, errs.errs...)
+	}
+	return b
+}
+
 // Wrap sets the error to be wrapped when building a Problem. See Problem.Error and Problem.Unwrap for more information.
 //
 // Additionally, more control can be achieved over the scenario where err's tree contains a Problem by passing an
@@ -429,21 +627,32 @@ func (b *Builder) UUID(flags ...Flag) *Builder {
 //
 // If no Unwrapper is provided, Generator.Unwrapper is used from Builder.Generator if not nil, otherwise from
 // DefaultGenerator. If an Unwrapper could still not be resolved, it defaults to PropagatedFieldUnwrapper.
+//
+// If err wraps multiple errors itself and is not a Problem (e.g. the result of errors.Join), each of those errors is
+// treated as a separate cause (see Problem.Causes) and only the cause selected by Generator.CauseSelector is passed to
+// the Unwrapper.
 func (b *Builder) Wrap(err error, unwrapper ...Unwrapper) *Builder {
-	var _unwrapper Unwrapper
-	if len(unwrapper) > 0 {
-		_unwrapper = unwrapper[0]
-	} else if g := b.Generator; g != nil {
-		_unwrapper = g.Unwrapper
-	} else {
-		_unwrapper = DefaultGenerator.Unwrapper
+	var errs []error
+	if err != nil {
+		errs = []error{err}
 	}
-	if _unwrapper == nil {
-		_unwrapper = unwrapPropagatedFields
+	return b.wrap(errs, unwrapper)
+}
+
+// WrapAll sets the errors to be wrapped when building a Problem, each of which is treated as a separate cause. See
+// Problem.Causes, Problem.Error, and Problem.Unwrap for more information. Any nil errors are ignored.
+//
+// Like Builder.Wrap, Generator.Unwrapper is used to decide what, if any, information from a wrapped Problem is to be
+// used when building a Problem. However, only the cause selected by Generator.CauseSelector is passed to the
+// Unwrapper.
+func (b *Builder) WrapAll(errs ...error) *Builder {
+	var _errs []error
+	for _, err := range errs {
+		if err != nil {
+			_errs = append(_errs, err)
+		}
 	}
-	b.err = err
-	b.problem = _unwrapper(err)
-	return b
+	return b.wrap(_errs, nil)
 }
 
 // build effectively does the heavy lifting for Builder.Problem but allows control over the number of stack frames to be
@@ -457,18 +666,30 @@ func (b *Builder) build(skipStackFrames int) *Problem {
 	if g == nil {
 		g = GetGenerator(ctx)
 	}
+	if mb, mapped := b.mapError(ctx, g); mapped {
+		return mb.build(skipStackFrames)
+	}
+	typeURI := b.buildType(g)
+	extensions := b.buildExtensions(ctx, g)
+	translateCtx := usingTranslationExtensions(ctx, extensions)
+	detail, detailKey := b.findDetail(translateCtx, g)
+	title, titleKey := b.buildTitle(translateCtx, g)
 	return &Problem{
 		Code:       b.buildCode(),
-		Detail:     b.buildDetail(ctx, g),
-		Extensions: b.buildExtensions(),
+		Detail:     b.buildDetail(detail),
+		Extensions: extensions,
 		Instance:   b.buildInstance(),
 		Stack:      b.buildStack(g, skipStackFrames),
 		Status:     b.buildStatus(),
-		Title:      b.buildTitle(ctx, g),
-		Type:       b.buildType(g),
+		Title:      title,
+		Type:       typeURI,
 		UUID:       b.buildUUID(ctx, g),
-		err:        b.err,
-		logInfo:    b.buildLogInfo(ctx, g, skipStackFrames),
+		definition: b.buildDefinition(g, typeURI),
+		errs:       slices.Clone(b.errs),
+		header:     b.buildHeader(),
+		keys:       translationKeys{detail: detailKey, title: titleKey},
+		logInfo:    b.buildLogInfo(ctx, g, detail, skipStackFrames),
+		retry:      b.buildRetry(),
 	}
 }
 
@@ -477,21 +698,61 @@ func (b *Builder) buildCode() Code {
 	return firstNonZeroValue(b.code, b.problem.Code, b.def.Code)
 }
 
-// buildDetail returns the most suitable detail for building a Problem.
-func (b *Builder) buildDetail(ctx context.Context, gen *Generator) string {
-	var v string
-	if v = gen.translateOrElse(ctx, b.detailKey, b.detail); v != "" {
-		return v
+// buildDefinition returns the most suitable Definition to be linked to a Problem being built with the given type URI
+// reference.
+//
+// Priority is given to any Definition explicitly provided, followed by that of any Problem unwrapped, and finally any
+// Definition registered against typeURI within Generator.Registry.
+func (b *Builder) buildDefinition(gen *Generator, typeURI string) *Definition {
+	if b.defined {
+		def := b.def
+		return &def
 	}
-	if v = b.problem.Detail; v != "" {
-		return v
+	if b.problem.definition != nil {
+		return b.problem.definition
+	}
+	if def, found := gen.Registry.lookup(typeURI); found {
+		return def
+	}
+	return nil
+}
+
+// buildDetail returns the given detail, as found by findDetail, if detailFlag contains FlagField. Otherwise, an empty
+// string is returned.
+func (b *Builder) buildDetail(detail string) string {
+	if checkFlag(b.detailFlag.OrElse(FlagField|FlagLog), FlagField) {
+		return detail
+	}
+	return ""
+}
+
+// buildExtensions returns a shallow clone of the most suitable extensions for building a Problem, including any
+// validation errors.
+func (b *Builder) buildExtensions(ctx context.Context, gen *Generator) map[string]any {
+	extensions := maps.Clone(firstNonNilMap(b.extensions, b.problem.Extensions, b.def.Extensions))
+	if len(b.validationErrs) > 0 {
+		if extensions == nil {
+			extensions = make(map[string]any, 1)
+		}
+		extensions[ValidationErrorsExtensionKey] = gen.buildFieldErrors(ctx, b.validationErrs)
 	}
-	return gen.translateOrElse(ctx, b.def.DetailKey, b.def.Detail)
+	return extensions
 }
 
-// buildExtensions returns a shallow clone of the most suitable extensions for building a Problem.
-func (b *Builder) buildExtensions() map[string]any {
-	return maps.Clone(firstNonNilMap(b.extensions, b.problem.Extensions, b.def.Extensions))
+// buildHeader returns the most suitable HTTP response headers for building a Problem.
+//
+// Unlike extensions, headers are merged by key, with those explicitly defined taking precedence over those of any
+// Problem unwrapped, followed by those of the Definition. nil is returned if no headers are found.
+func (b *Builder) buildHeader() http.Header {
+	size := len(b.def.Header) + len(b.problem.header) + len(b.header)
+	if size == 0 {
+		return nil
+	}
+	header := make(http.Header, size)
+	mergeHeader(header, b.def.Header)
+	mergeHeader(header, b.problem.header)
+	mergeHeader(header, b.header)
+	return header
 }
 
 // buildInstance returns the most suitable instance URI reference for building a Problem.
@@ -502,11 +763,24 @@ func (b *Builder) buildInstance() string {
 // buildLogInfo returns the most suitable log information for building a Problem.
 //
 // The stack trace or UUID in the returned logInfo will be empty if stackFlag or uuidFlag do not contain FlagLog
-// respectively.
+// respectively. Similarly, the given detail, as found by findDetail, is hidden from logs if detailFlag does not contain
+// FlagLog.
+//
+// Only information that is not visible on the fields of the Problem is retained within the Detail and Extensions of the
+// returned LogInfo, which Problem.LogInfo combines with the fields of the Problem.
 //
 // skipStackFrames is the number of frames before recording the stack trace with zero identifying the caller of
 // buildLogInfo.
-func (b *Builder) buildLogInfo(ctx context.Context, gen *Generator, skipStackFrames int) (info LogInfo) {
+func (b *Builder) buildLogInfo(ctx context.Context, gen *Generator, detail string, skipStackFrames int) (info LogInfo) {
+	switch detailFlag := b.detailFlag.OrElse(FlagField | FlagLog); {
+	case !checkFlag(detailFlag, FlagLog):
+		info.detailHidden = true
+	case !checkFlag(detailFlag, FlagField):
+		info.Detail = detail
+	case detail == "":
+		info.Detail = b.problem.logInfo.Detail
+	}
+	info.Extensions = b.buildLogExtensions()
 	info.Level = firstNonZeroValue(b.logLevel, b.problem.logInfo.Level, gen.logLevel(b.def.Type))
 	if checkFlag(b.stackFlag.OrElse(gen.StackFlag), FlagLog) {
 		info.Stack = b.getStack(skipStackFrames + 1)
@@ -517,6 +791,43 @@ func (b *Builder) buildLogInfo(ctx context.Context, gen *Generator, skipStackFra
 	return
 }
 
+// buildLogExtensions returns a shallow clone of the most suitable extensions that are only visible in logs for building
+// a Problem.
+//
+// Log-only extensions are merged by key, with those explicitly defined taking precedence over those of any Problem
+// unwrapped. nil is returned if no log-only extensions are found.
+func (b *Builder) buildLogExtensions() map[string]any {
+	size := len(b.problem.logInfo.Extensions) + len(b.logExtensions)
+	if size == 0 {
+		return nil
+	}
+	extensions := make(map[string]any, size)
+	maps.Copy(extensions, b.problem.logInfo.Extensions)
+	maps.Copy(extensions, b.logExtensions)
+	return extensions
+}
+
+// buildRetry returns the most suitable retry information for building a Problem.
+//
+// Unless explicitly defined, a Problem is considered retryable if any retryable information is found or if it has a
+// retry delay.
+func (b *Builder) buildRetry() retryInfo {
+	info := retryInfo{after: b.retryAfter, at: b.retryAt}
+	if info.after <= 0 && info.at.IsZero() {
+		info.after, info.at = b.problem.retry.after, b.problem.retry.at
+	}
+	if info.after <= 0 && info.at.IsZero() {
+		info.after = b.def.Type.RetryAfter
+		if b.def.RetryAfter > 0 {
+			info.after = b.def.RetryAfter
+		}
+	}
+	info.retryable = b.retryable.OrElseGet(func() bool {
+		return b.problem.retry.retryable || b.def.Type.Retryable || info.after > 0 || !info.at.IsZero()
+	})
+	return info
+}
+
 // buildStack returns the most suitable stack trace for building a Problem.
 //
 // An empty string is returned if stackFlag does not contain FlagField.
@@ -536,19 +847,20 @@ func (b *Builder) buildStatus() int {
 	return firstNonZeroValue(b.status, b.problem.Status, b.def.Type.Status, http.StatusInternalServerError)
 }
 
-// buildTitle returns the most suitable title for building a Problem.
-func (b *Builder) buildTitle(ctx context.Context, gen *Generator) string {
+// buildTitle returns the most suitable title for building a Problem along with the translation key from which it was
+// resolved, if any.
+func (b *Builder) buildTitle(ctx context.Context, gen *Generator) (string, any) {
 	var v string
 	if v = gen.translateOrElse(ctx, b.titleKey, b.title); v != "" {
-		return v
+		return v, b.titleKey
 	}
 	if v = b.problem.Title; v != "" {
-		return v
+		return v, b.problem.keys.title
 	}
 	if v = gen.translateOrElse(ctx, b.def.Type.TitleKey, b.def.Type.Title); v != "" {
-		return v
+		return v, b.def.Type.TitleKey
 	}
-	return DefaultTitle
+	return DefaultTitle, nil
 }
 
 // buildType returns the most suitable type URI reference for building a Problem. DefaultTypeURI is returned if no
@@ -567,6 +879,30 @@ func (b *Builder) buildUUID(ctx context.Context, gen *Generator) string {
 	return ""
 }
 
+// findDetail returns the most suitable detail for building a Problem, regardless of its visibility, along with the
+// translation key from which it was resolved, if any.
+func (b *Builder) findDetail(ctx context.Context, gen *Generator) (string, any) {
+	var v string
+	if v = gen.translateOrElse(ctx, b.detailKey, b.detail); v != "" {
+		return v, b.detailKey
+	}
+	if v = b.problem.Detail; v != "" {
+		return v, b.problem.keys.detail
+	}
+	if v = gen.translateOrElse(ctx, b.def.DetailKey, b.def.Detail); v != "" {
+		return v, b.def.DetailKey
+	}
+	return "", nil
+}
+
+// getGenerator returns Builder.Generator if not nil, otherwise DefaultGenerator.
+func (b *Builder) getGenerator() *Generator {
+	if g := b.Generator; g != nil {
+		return g
+	}
+	return DefaultGenerator
+}
+
 // getStack returns a lazily captured stack trace to be used for building a Problem. Priority is given to any existing
 // stack contained within problem.
 //
@@ -606,6 +942,52 @@ func (b *Builder) getUUID(ctx context.Context, gen *Generator) string {
 	return b.uuid
 }
 
+// mapError returns a shallow clone of the Builder whose wrapped error has been mapped using Generator.Mapper, if
+// possible.
+//
+// The wrapped error is only mapped if no Definition has been explicitly provided, it is not a Describer, no Problem is
+// contained within its tree, and nothing was unwrapped from it. When mapped, the mapped Problem is used in place of
+// the unwrapped Problem so that any explicitly defined fields still take precedence, and the Definition it was mapped
+// to is used. When multiple errors are wrapped, only the cause selected by Generator.CauseSelector is mapped.
+func (b *Builder) mapError(ctx context.Context, gen *Generator) (*Builder, bool) {
+	if len(b.errs) == 0 || gen.Mapper == nil || !reflect.ValueOf(b.def).IsZero() || !reflect.ValueOf(b.problem).IsZero() {
+		return nil, false
+	}
+	cause := gen.selectCause(b.errs)
+	if cause == nil || isDescribing(cause) {
+		return nil, false
+	}
+	if _, isProblem := gen.as(cause); isProblem {
+		return nil, false
+	}
+	prob, def, mapped := gen.mapError(ctx, cause)
+	if !mapped {
+		return nil, false
+	}
+	mb := *b
+	mb.def = def
+	mb.problem = *prob
+	return &mb, true
+}
+
+// wrap sets the given errors to be wrapped when building a Problem, passing the cause selected by
+// Generator.CauseSelector to the Unwrapper provided, if any, otherwise Generator.Unwrapper.
+func (b *Builder) wrap(errs []error, unwrapper []Unwrapper) *Builder {
+	g := b.getGenerator()
+	var _unwrapper Unwrapper
+	if len(unwrapper) > 0 {
+		_unwrapper = unwrapper[0]
+	} else {
+		_unwrapper = g.Unwrapper
+	}
+	if _unwrapper == nil {
+		_unwrapper = unwrapPropagatedFields
+	}
+	b.errs = errs
+	b.problem = _unwrapper(g.selectCause(errs))
+	return b
+}
+
 // Build returns a Builder for the Generator with context.Background which can be used to construct problems.
 func (g *Generator) Build() *Builder {
 	return &Builder{
@@ -665,6 +1047,16 @@ func firstNonZeroValue[T comparable](values ...T) T {
 	return zero
 }
 
+// mergeHeader sets a clone of the values of each key within src in dst, canonicalizing each key in the same way as
+// http.Header.Set. Keys without any values are ignored.
+func mergeHeader(dst, src http.Header) {
+	for k, vs := range src {
+		if len(vs) > 0 {
+			dst[textproto.CanonicalMIMEHeaderKey(k)] = slices.Clone(vs)
+		}
+	}
+}
+
 // resolveFlag returns an optional Flag based on the given flags.
 //
 // If flags is empty, this is considered equal to passing FlagField and FlagLog. If FlagDisable is given, all other
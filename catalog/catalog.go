@@ -0,0 +1,162 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package catalog provides support for loading a catalog of problem.Type and problem.Definition values from YAML and/or
+// JSON files, allowing problems to be owned as data rather than Go source.
+//
+// A catalog file contains named types and definitions, where each definition may either declare its type inline or
+// reference a named type using "typeRef". For example;
+//
+//	types:
+//	  NotFound:
+//	    logLevel: warn
+//	    status: 404
+//	    title: Not Found
+//	    uri: https://example.com/probs/not-found
+//	definitions:
+//	  UserNotFound:
+//	    code: USER-404
+//	    detail: The requested user could not be found
+//	    typeRef: NotFound
+//	  OutOfCredit:
+//	    code: BILL-403
+//	    extensions:
+//	      balance: 0
+//	    type:
+//	      status: 403
+//	      title: You do not have enough credit.
+//	      uri: https://example.com/probs/out-of-credit
+//
+// All catalogs are validated when loaded to ensure that each Code is valid for the problem.Generator, no Code or type
+// URI reference is shared between unrelated entries, and that each status represents an HTTP client or server error.
+package catalog
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/jay-babu/go-problem"
+)
+
+type (
+	// Catalog is a validated lookup table of named problem.Type and problem.Definition values.
+	//
+	// Any problem.Definition that referenced a named problem.Type has already been resolved to contain it.
+	Catalog struct {
+		// definitions contains the resolved definition entries mapped to their names.
+		definitions map[string]DefinitionEntry
+		// types contains the types mapped to their names.
+		types map[string]problem.Type
+	}
+
+	// DefinitionEntry represents a single named definition within a catalog file.
+	DefinitionEntry struct {
+		problem.Definition `yaml:",inline"`
+		// Constructor indicates whether a constructor function, accepting a parameter for each of Members, is desired
+		// for the definition when generating code (e.g. using problemgen).
+		Constructor bool `json:"constructor,omitempty" yaml:"constructor,omitempty"`
+		// Members declares the extension members expected to be present on problems generated from the definition,
+		// which are used to generate typed parameters for any constructor, as well as documentation.
+		//
+		// Each Member.Name must be unique within Members and must be a valid extension key (i.e. not empty or
+		// reserved).
+		Members []Member `json:"members,omitempty" yaml:"members,omitempty"`
+		// TypeRef is the name of a problem.Type within the catalog to be used as Definition.Type.
+		//
+		// If TypeRef is not empty, Definition.Type must be empty.
+		TypeRef string `json:"typeRef,omitempty" yaml:"typeRef,omitempty"`
+	}
+
+	// Member declares an extension member expected to be present on problems generated from a definition.
+	Member struct {
+		// Description is a human-readable description of the extension member.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Import is the path of the Go package, if any, that must be imported in order to use Type.
+		Import string `json:"import,omitempty" yaml:"import,omitempty"`
+		// Name is the key of the extension member.
+		Name string `json:"name" yaml:"name"`
+		// Type is the Go type expression of the extension member (e.g. "string", "[]int", or "time.Time").
+		//
+		// If Type is empty, "any" is used.
+		Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	}
+
+	// File represents the contents of a catalog file.
+	File struct {
+		// Definitions contains the definition entries mapped to their names.
+		Definitions map[string]DefinitionEntry `json:"definitions,omitempty" yaml:"definitions,omitempty"`
+		// Types contains the types mapped to their names, which can be referenced using DefinitionEntry.TypeRef.
+		Types map[string]problem.Type `json:"types,omitempty" yaml:"types,omitempty"`
+	}
+)
+
+// Definition returns the problem.Definition with the given name within the Catalog, if any.
+func (c *Catalog) Definition(name string) (problem.Definition, bool) {
+	entry, found := c.definitions[name]
+	return entry.Definition, found
+}
+
+// DefinitionNames returns the sorted names of all definitions within the Catalog.
+func (c *Catalog) DefinitionNames() []string {
+	return slices.Sorted(maps.Keys(c.definitions))
+}
+
+// Definitions returns a copy of all definitions within the Catalog mapped to their names.
+func (c *Catalog) Definitions() map[string]problem.Definition {
+	defs := make(map[string]problem.Definition, len(c.definitions))
+	for name, entry := range c.definitions {
+		defs[name] = entry.Definition
+	}
+	return defs
+}
+
+// Entry returns the DefinitionEntry with the given name within the Catalog, if any.
+//
+// Unlike Catalog.Definition, the returned DefinitionEntry retains any TypeRef that was used to resolve its type.
+func (c *Catalog) Entry(name string) (DefinitionEntry, bool) {
+	entry, found := c.definitions[name]
+	return entry, found
+}
+
+// MustDefinition is a convenient shorthand for calling Catalog.Definition that panics if no problem.Definition exists
+// with the given name.
+func (c *Catalog) MustDefinition(name string) problem.Definition {
+	def, found := c.Definition(name)
+	if !found {
+		panic("problem catalog definition not found: " + name)
+	}
+	return def
+}
+
+// Type returns the problem.Type with the given name within the Catalog, if any.
+func (c *Catalog) Type(name string) (problem.Type, bool) {
+	t, found := c.types[name]
+	return t, found
+}
+
+// TypeNames returns the sorted names of all types within the Catalog.
+func (c *Catalog) TypeNames() []string {
+	return slices.Sorted(maps.Keys(c.types))
+}
+
+// Types returns a copy of all types within the Catalog mapped to their names.
+func (c *Catalog) Types() map[string]problem.Type {
+	return maps.Clone(c.types)
+}
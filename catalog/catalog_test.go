@@ -0,0 +1,127 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package catalog_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/jay-babu/go-problem/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"types.yaml": {Data: []byte(`
+types:
+  NotFound:
+    docs:
+      description: The requested resource does not exist.
+      links:
+        - title: Runbook
+          url: https://example.com/runbooks/not-found
+      owner: platform
+      resolution:
+        - Check the identifier.
+      tags: [http]
+    logLevel: warn
+    status: 404
+    title: Not Found
+    uri: https://example.com/probs/not-found
+`)},
+		"definitions/user.json": {Data: []byte(`{
+  "definitions": {
+    "UserNotFound": {"code": "USER-404", "detail": "User not found", "typeRef": "NotFound"},
+    "OutOfCredit": {
+      "code": "BILL-403",
+      "extensions": {"balance": 0},
+      "type": {"status": 403, "title": "You do not have enough credit.", "uri": "https://example.com/probs/out-of-credit"}
+    }
+  }
+}`)},
+		"README.md": {Data: []byte(`ignored`)},
+	}
+
+	c, err := catalog.LoadFS(fsys)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OutOfCredit", "UserNotFound"}, c.DefinitionNames())
+	assert.Equal(t, []string{"NotFound"}, c.TypeNames())
+
+	notFound := problem.Type{
+		Docs: problem.Documentation{
+			Description: "The requested resource does not exist.",
+			Links:       []problem.Link{{Title: "Runbook", URL: "https://example.com/runbooks/not-found"}},
+			Owner:       "platform",
+			Resolution:  []string{"Check the identifier."},
+			Tags:        []string{"http"},
+		},
+		LogLevel: problem.LogLevelWarn,
+		Status:   404,
+		Title:    "Not Found",
+		URI:      "https://example.com/probs/not-found",
+	}
+	def, found := c.Definition("UserNotFound")
+	require.True(t, found)
+	assert.Equal(t, problem.Definition{Code: "USER-404", Detail: "User not found", Type: notFound}, def)
+
+	entry, found := c.Entry("UserNotFound")
+	require.True(t, found)
+	assert.Equal(t, "NotFound", entry.TypeRef)
+
+	def = c.MustDefinition("OutOfCredit")
+	assert.Equal(t, map[string]any{"balance": float64(0)}, def.Extensions)
+	assert.Equal(t, 403, def.Type.Status)
+}
+
+func TestLoader_Load_Invalid(t *testing.T) {
+	_, err := catalog.Loader{}.Load(catalog.File{
+		Types: map[string]problem.Type{
+			"A": {Status: 404, URI: "https://example.com/probs/a"},
+			"B": {Status: 200, URI: "https://example.com/probs/a"},
+		},
+		Definitions: map[string]catalog.DefinitionEntry{
+			"One":   {Definition: problem.Definition{Code: "X-1"}, TypeRef: "A"},
+			"Two":   {Definition: problem.Definition{Code: "X-1"}, TypeRef: "Missing"},
+			"Three": {Definition: problem.Definition{Code: "invalid"}},
+			"Four":  {Definition: problem.Definition{Type: problem.Type{Status: 500}}, TypeRef: "A"},
+			"Five": {
+				Members: []catalog.Member{{Name: "status"}, {Name: ""}, {Name: "balance"}, {Name: "balance"}},
+				TypeRef: "A",
+			},
+		},
+	})
+	require.ErrorIs(t, err, catalog.ErrCatalog)
+	for _, msg := range []string{
+		`type "B" has status 200 which is not a client or server error`,
+		`type "B" has type URI "https://example.com/probs/a" already used by type "A"`,
+		`definition "Two" references unknown type "Missing"`,
+		`definition "Two" has code "X-1" already used by definition "One"`,
+		`definition "Three" has invalid code`,
+		`definition "Four" cannot declare both type and typeRef`,
+		`definition "Five" has invalid member "status": extension key is reserved`,
+		`definition "Five" has invalid member "": extension key cannot be empty`,
+		`definition "Five" has duplicate member "balance"`,
+	} {
+		assert.ErrorContains(t, err, msg)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/jay-babu/go-problem"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Loader is used to load a Catalog from catalog files.
+	Loader struct {
+		// Generator is the problem.Generator used to validate each problem.Code within the catalog files.
+		//
+		// If nil, problem.DefaultGenerator is used.
+		Generator *problem.Generator
+	}
+
+	// source is a File along with the name of the catalog file from which it was parsed, if any.
+	source struct {
+		file File
+		name string
+	}
+)
+
+// ErrCatalog is returned when a catalog file cannot be parsed or contains invalid entries.
+var ErrCatalog = errors.New("invalid problem catalog")
+
+// Load returns a Catalog containing all entries within the given files.
+//
+// An error is returned if the same name is used for more than one type or definition across all files or if any entry
+// is invalid. Errors for all invalid entries are joined, each wrapping ErrCatalog.
+func (l Loader) Load(files ...File) (*Catalog, error) {
+	sources := make([]source, len(files))
+	for i, file := range files {
+		sources[i] = source{file: file}
+	}
+	return l.load(sources)
+}
+
+// LoadFile returns a Catalog containing all entries within the catalog file at the given path.
+//
+// The format of the file is derived from its extension, where ".json" is parsed as JSON while ".yaml" and ".yml" are
+// parsed as YAML.
+//
+// An error is returned if the file cannot be read or parsed, or it contains any invalid entries. Errors for all invalid
+// entries are joined, each wrapping ErrCatalog.
+func (l Loader) LoadFile(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := parseFile(filepath.Base(path), data)
+	if err != nil {
+		return nil, err
+	}
+	return l.load([]source{{file: file, name: path}})
+}
+
+// LoadFS returns a Catalog containing all entries within the catalog files found in the given fs.FS.
+//
+// If any patterns are provided, only files matching at least one of them (see fs.Glob) are loaded. Otherwise, all files
+// within fsys with a ".json", ".yaml", or ".yml" extension are loaded. See Loader.LoadFile for more information.
+//
+// An error is returned if any file cannot be read or parsed, the same name is used for more than one type or
+// definition across all files, or any entry is invalid. Errors for all invalid entries are joined, each wrapping
+// ErrCatalog.
+func (l Loader) LoadFS(fsys fs.FS, patterns ...string) (*Catalog, error) {
+	names, err := findFiles(fsys, patterns)
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]source, 0, len(names))
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parseFile(name, data)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source{file: file, name: name})
+	}
+	return l.load(sources)
+}
+
+// load returns a Catalog containing all entries within the given sources, after validating them.
+func (l Loader) load(sources []source) (*Catalog, error) {
+	gen := l.Generator
+	if gen == nil {
+		gen = problem.DefaultGenerator
+	}
+
+	var errs []error
+	c := &Catalog{
+		definitions: make(map[string]DefinitionEntry),
+		types:       make(map[string]problem.Type),
+	}
+	defSources := make(map[string]string)
+	typeSources := make(map[string]string)
+	for _, src := range sources {
+		for name, t := range src.file.Types {
+			if other, dup := typeSources[name]; dup {
+				errs = append(errs, fmt.Errorf("%w: type %q declared in both %q and %q", ErrCatalog, name, other, src.name))
+				continue
+			}
+			typeSources[name] = src.name
+			c.types[name] = t
+		}
+		for name, entry := range src.file.Definitions {
+			if other, dup := defSources[name]; dup {
+				errs = append(errs, fmt.Errorf("%w: definition %q declared in both %q and %q", ErrCatalog, name, other, src.name))
+				continue
+			}
+			defSources[name] = src.name
+			c.definitions[name] = entry
+		}
+	}
+
+	typeURIs := make(map[string]string)
+	for _, name := range c.TypeNames() {
+		t := c.types[name]
+		owner := fmt.Sprintf("type %q", name)
+		if name == "" {
+			errs = append(errs, fmt.Errorf("%w: type name cannot be empty", ErrCatalog))
+		}
+		errs = append(errs, validateType(owner, t, typeURIs)...)
+	}
+
+	codes := make(map[problem.Code]string)
+	for _, name := range c.DefinitionNames() {
+		entry := c.definitions[name]
+		owner := fmt.Sprintf("definition %q", name)
+		if name == "" {
+			errs = append(errs, fmt.Errorf("%w: definition name cannot be empty", ErrCatalog))
+		}
+
+		if entry.TypeRef != "" {
+			if !reflect.ValueOf(entry.Type).IsZero() {
+				errs = append(errs, fmt.Errorf("%w: %s cannot declare both type and typeRef", ErrCatalog, owner))
+			} else if t, found := c.types[entry.TypeRef]; !found {
+				errs = append(errs, fmt.Errorf("%w: %s references unknown type %q", ErrCatalog, owner, entry.TypeRef))
+			} else {
+				entry.Type = t
+				c.definitions[name] = entry
+			}
+		} else {
+			errs = append(errs, validateType(owner, entry.Type, typeURIs)...)
+		}
+
+		errs = append(errs, validateMembers(owner, entry.Members)...)
+
+		if code := entry.Code; code != "" {
+			if err := gen.ValidateCode(code); err != nil {
+				errs = append(errs, fmt.Errorf("%w: %s has invalid code: %w", ErrCatalog, owner, err))
+			} else if other, dup := codes[code]; dup {
+				errs = append(errs, fmt.Errorf("%w: %s has code %q already used by %s", ErrCatalog, owner, code, other))
+			} else {
+				codes[code] = owner
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LoadFile is a convenient shorthand for calling Loader.LoadFile on a Loader using problem.DefaultGenerator.
+func LoadFile(path string) (*Catalog, error) {
+	return Loader{}.LoadFile(path)
+}
+
+// LoadFS is a convenient shorthand for calling Loader.LoadFS on a Loader using problem.DefaultGenerator.
+func LoadFS(fsys fs.FS, patterns ...string) (*Catalog, error) {
+	return Loader{}.LoadFS(fsys, patterns...)
+}
+
+// findFiles returns the sorted names of all files within the given fs.FS that match any of the patterns provided or, if
+// there are none, those that have a supported extension.
+func findFiles(fsys fs.FS, patterns []string) ([]string, error) {
+	var names []string
+	if len(patterns) > 0 {
+		for _, pattern := range patterns {
+			matches, err := fs.Glob(fsys, pattern)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, matches...)
+		}
+	} else {
+		err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && isSupportedExt(path.Ext(name)) {
+				names = append(names, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
+
+// isSupportedExt returns whether the given file extension is that of a supported catalog file format.
+func isSupportedExt(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseFile parses the given data of the catalog file with the name provided, using the format derived from its
+// extension.
+//
+// Unknown fields are rejected to catch typos within catalog files as early as possible.
+func parseFile(name string, data []byte) (File, error) {
+	var (
+		err  error
+		file File
+	)
+	switch ext := strings.ToLower(path.Ext(name)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&file)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&file)
+	default:
+		return File{}, fmt.Errorf("%w: unsupported file extension %q: %s", ErrCatalog, ext, name)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return File{}, fmt.Errorf("%w: unable to parse %s: %w", ErrCatalog, name, err)
+	}
+	return file, nil
+}
+
+// validateMembers validates the given members belonging to the owner provided, returning any errors encountered.
+func validateMembers(owner string, members []Member) []error {
+	var errs []error
+	names := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		if err := problem.ValidateExtensionKey(member.Name); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s has invalid member %q: %w", ErrCatalog, owner, member.Name, err))
+			continue
+		}
+		if _, dup := names[member.Name]; dup {
+			errs = append(errs, fmt.Errorf("%w: %s has duplicate member %q", ErrCatalog, owner, member.Name))
+			continue
+		}
+		names[member.Name] = struct{}{}
+	}
+	return errs
+}
+
+// validateType validates the given problem.Type belonging to the owner provided, returning any errors encountered.
+//
+// typeURIs is used to detect any type URI reference that is shared with another owner and is updated accordingly.
+func validateType(owner string, t problem.Type, typeURIs map[string]string) []error {
+	var errs []error
+	if s := t.Status; s != 0 && (s < 400 || s > 599) {
+		errs = append(errs, fmt.Errorf("%w: %s has status %d which is not a client or server error", ErrCatalog, owner, s))
+	}
+	if uri := t.URI; uri != "" {
+		if other, dup := typeURIs[uri]; dup {
+			errs = append(errs, fmt.Errorf("%w: %s has type URI %q already used by %s", ErrCatalog, owner, uri, other))
+		} else {
+			typeURIs[uri] = owner
+		}
+	}
+	return errs
+}
@@ -0,0 +1,301 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// ReadOptions contains options that can be used when reading problems from HTTP responses.
+//
+// All fields are optional with default behaviour clearly documented.
+type ReadOptions struct {
+	// MaxBodySize is the maximum number of bytes that will be read from the body of the HTTP response.
+	//
+	// If less than or equal to zero, DefaultMaxBodySize will be used.
+	MaxBodySize int64
+}
+
+// DefaultMaxBodySize is the default maximum number of bytes read from the body of an HTTP response when reading a
+// Problem.
+const DefaultMaxBodySize int64 = 1 << 20 // 1MB
+
+// maxDetailSnippetLen is the maximum number of bytes of the body of an HTTP response used as the detail of a Problem
+// synthesized from an HTTP response that does not contain a problem document.
+const maxDetailSnippetLen = 512
+
+// ErrBodyTooLarge is returned when the body of an HTTP response exceeds ReadOptions.MaxBodySize.
+var ErrBodyTooLarge = errors.New("problem response body too large")
+
+// errNilResponse is returned when attempting to read a Problem from a nil HTTP response.
+var errNilResponse = errors.New("unable to read problem response: response is nil")
+
+// apply applies the fields from the given ReadOptions, if any and where applicable.
+//
+// The fields of any ReadOptions found are handled as follows:
+//
+//   - MaxBodySize is applied if greater than zero
+func (ro ReadOptions) apply(opts []ReadOptions) ReadOptions {
+	if len(opts) > 0 {
+		if _opts := opts[0]; _opts.MaxBodySize > 0 {
+			ro.MaxBodySize = _opts.MaxBodySize
+		}
+	}
+	return ro
+}
+
+// IsProblemResponse returns whether the Content-Type header of the given HTTP response indicates that its body contains
+// a problem document in either JSON or XML format.
+func IsProblemResponse(resp *http.Response) bool {
+	return resp != nil && problemMediaType(resp.Header) != ""
+}
+
+// CheckResponse returns a ResponseError if the given HTTP response has a client or server error status (i.e. 4xx or
+// 5xx) and contains a problem document, optionally using ReadOptions for more granular control. Otherwise, nil is
+// returned.
+//
+// Unlike Transport, CheckResponse is called on an HTTP response after it has been received, so it never interferes with
+// the http.RoundTripper contract. For example;
+//
+//	resp, err := client.Get("https://api.example.void/users/123")
+//	if err != nil {
+//		return err
+//	}
+//	defer resp.Body.Close()
+//	if err = CheckResponse(resp); err != nil {
+//		return err
+//	}
+//
+// The body of resp is always left readable, whether or not a ResponseError is returned, including when it exceeds
+// ReadOptions.MaxBodySize or cannot be decoded, in which case nil is returned. See Generator.ReadResponse for more
+// information on how the Problem is read.
+func (g *Generator) CheckResponse(resp *http.Response, opts ...ReadOptions) error {
+	if resp == nil || !isErrorStatus(resp.StatusCode) || !IsProblemResponse(resp) {
+		return nil
+	}
+	_opts := ReadOptions{MaxBodySize: DefaultMaxBodySize}.apply(opts)
+	prob, ok := g.convertResponse(resp, _opts.MaxBodySize)
+	if !ok {
+		return nil
+	}
+	return &ResponseError{Problem: prob, Response: resp}
+}
+
+// convertResponse reads a Problem from the body of the given HTTP response, which is expected to contain a problem
+// document, returning false if the body exceeds maxSize or cannot be read or decoded.
+//
+// Either way, the body of resp is replaced so that it can be read again by the caller. The original body is closed only
+// if the Problem was read successfully, as it will have been consumed entirely.
+func (g *Generator) convertResponse(resp *http.Response, maxSize int64) (*Problem, bool) {
+	if resp.Body == nil {
+		resp.Body = http.NoBody
+	}
+	body, err := readBody(resp, maxSize)
+	if err != nil {
+		resp.Body = &replayBody{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		return nil, false
+	}
+	prob, err := g.decodeResponse(resp, body)
+	if err != nil {
+		resp.Body = &replayBody{Reader: bytes.NewReader(body), Closer: resp.Body}
+		return nil, false
+	}
+
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return prob, true
+}
+
+// ReadResponse reads a Problem from the body of the given HTTP response, optionally using ReadOptions for more granular
+// control.
+//
+// If the Content-Type header of resp indicates that the body contains a problem document (i.e. ContentTypeJSON or
+// ContentTypeXML), it is decoded accordingly, with Problem.Status and Problem.Type being populated from resp and
+// DefaultTypeURI respectively when absent from the document. Otherwise, a Problem is synthesized using the status code
+// of resp along with a snippet of its body as the detail.
+//
+// Any decoded Problem is linked to any Definition and decoded into any Go type registered against its type URI
+// reference within Generator.Registry. See Registry for more information.
+//
+// If resp has a valid Retry-After header, the Problem is considered retryable and its delay can be obtained using
+// Problem.RetryAfter. See Problem.Retryable for more information.
+//
+// The body of resp is read but not closed, which remains the responsibility of the caller. ErrBodyTooLarge is
+// returned if the body exceeds ReadOptions.MaxBodySize. An error is also returned if resp is nil, the body cannot be
+// read, or the problem document cannot be decoded.
+func (g *Generator) ReadResponse(resp *http.Response, opts ...ReadOptions) (*Problem, error) {
+	if resp == nil {
+		return nil, errNilResponse
+	}
+	_opts := ReadOptions{MaxBodySize: DefaultMaxBodySize}.apply(opts)
+	body, err := readBody(resp, _opts.MaxBodySize)
+	if err != nil {
+		return nil, err
+	}
+	return g.decodeResponse(resp, body)
+}
+
+// decodeResponse decodes a Problem from the given body read from the HTTP response provided. See
+// Generator.ReadResponse for more information.
+//
+// A problem document in JSON format is passed to Generator.Registry as-is so that any registered Go type is decoded
+// from the original document, rather than from the Problem after it has been populated from resp.
+//
+// An error is returned if the problem document cannot be decoded.
+func (g *Generator) decodeResponse(resp *http.Response, body []byte) (*Problem, error) {
+	var (
+		data []byte
+		err  error
+		prob Problem
+	)
+	switch problemMediaType(resp.Header) {
+	case ContentTypeJSON:
+		data = body
+		err = decodeJSONProblem(body, &prob)
+	case ContentTypeXML:
+		err = xml.Unmarshal(body, (*xmlProblem)(&prob))
+	default:
+		synthesized := g.synthesizeProblem(resp, body)
+		readRetryAfter(resp.Header, synthesized)
+		return synthesized, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode problem response: %w", err)
+	}
+
+	if prob.Status == 0 {
+		prob.Status = resp.StatusCode
+	}
+	if prob.Type == "" {
+		prob.Type = DefaultTypeURI
+	}
+	g.Registry.resolve(&prob, data)
+	readRetryAfter(resp.Header, &prob)
+	return &prob, nil
+}
+
+// synthesizeProblem returns a Problem for the given HTTP response whose body does not contain a problem document,
+// using its status code and a snippet of the body provided.
+func (g *Generator) synthesizeProblem(resp *http.Response, body []byte) *Problem {
+	title := http.StatusText(resp.StatusCode)
+	if title == "" {
+		title = DefaultTitle
+	}
+	return g.Build().
+		Status(resp.StatusCode).
+		Title(title).
+		Detail(detailSnippet(body)).
+		Stack(FlagDisable).
+		UUID(FlagDisable).
+		Problem()
+}
+
+// CheckResponse is a convenient shorthand for calling Generator.CheckResponse on the Generator within the
+// context.Context of the HTTP request that resulted in the given HTTP response, if any, otherwise DefaultGenerator.
+func CheckResponse(resp *http.Response, opts ...ReadOptions) error {
+	gen := DefaultGenerator
+	if resp != nil && resp.Request != nil {
+		gen = GetGenerator(resp.Request.Context())
+	}
+	return gen.CheckResponse(resp, opts...)
+}
+
+// ReadResponse is a convenient shorthand for calling Generator.ReadResponse on the Generator within the context.Context
+// of the HTTP request that resulted in the given HTTP response, if any, otherwise DefaultGenerator.
+func ReadResponse(resp *http.Response, opts ...ReadOptions) (*Problem, error) {
+	gen := DefaultGenerator
+	if resp != nil && resp.Request != nil {
+		gen = GetGenerator(resp.Request.Context())
+	}
+	return gen.ReadResponse(resp, opts...)
+}
+
+// detailSnippet returns a trimmed snippet of the given body that can be used as the detail of a Problem, ensuring that
+// it does not exceed maxDetailSnippetLen bytes and that no multibyte character is split.
+func detailSnippet(body []byte) string {
+	if len(body) > maxDetailSnippetLen {
+		body = body[:maxDetailSnippetLen]
+		for len(body) > 0 && !utf8.Valid(body) {
+			body = body[:len(body)-1]
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// isErrorStatus returns whether the given HTTP status is a client or server error status (i.e. 4xx or 5xx).
+func isErrorStatus(status int) bool {
+	return status >= 400 && status <= 599
+}
+
+// problemMediaType returns either ContentTypeJSON or ContentTypeXML if the Content-Type within the given header
+// indicates that the body contains a problem document, otherwise an empty string.
+func problemMediaType(header http.Header) string {
+	mt, _, err := mime.ParseMediaType(header.Get(contentTypeHeader))
+	if err != nil {
+		return ""
+	}
+	switch mt {
+	case ContentTypeJSON, ContentTypeXML:
+		return mt
+	default:
+		return ""
+	}
+}
+
+// readRetryAfter populates the retry information of the given Problem from the Retry-After header provided, if present
+// and valid, in which case the Problem is also considered retryable.
+func readRetryAfter(header http.Header, prob *Problem) {
+	if info, ok := parseRetryAfter(header.Get(retryAfterHeader)); ok {
+		prob.retry = info
+	}
+}
+
+// readBody reads the entire body of the given HTTP response, returning ErrBodyTooLarge if it exceeds maxSize.
+//
+// Any bytes that were read are returned, even if an error is also returned.
+func readBody(resp *http.Response, maxSize int64) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return body, fmt.Errorf("unable to read problem response: %w", err)
+	}
+	if int64(len(body)) > maxSize {
+		return body, fmt.Errorf("%w: exceeds %v bytes", ErrBodyTooLarge, maxSize)
+	}
+	return body, nil
+}
+
+// replayBody is used to replace the body of an HTTP response after it has been (partially) read, allowing the bytes
+// that were read to be read again while still closing the original body.
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
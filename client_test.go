@@ -0,0 +1,167 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestResponse returns an HTTP response with the given status code, Content-Type header, and body.
+func newTestResponse(status int, contentType, body string) *http.Response {
+	return &http.Response{
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{"Content-Type": {contentType}},
+		StatusCode: status,
+	}
+}
+
+func TestReadResponse_JSON(t *testing.T) {
+	resp := newTestResponse(http.StatusForbidden, problem.ContentTypeJSONUTF8, `{
+		"title": "You do not have enough credit.",
+		"detail": "Your current balance is 30, but that costs 50.",
+		"instance": "/account/12345/msgs/abc",
+		"balance": 30
+	}`)
+	prob, err := problem.ReadResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, prob.Status)
+	assert.Equal(t, problem.DefaultTypeURI, prob.Type)
+	assert.Equal(t, "You do not have enough credit.", prob.Title)
+	assert.Equal(t, "Your current balance is 30, but that costs 50.", prob.Detail)
+	assert.Equal(t, "/account/12345/msgs/abc", prob.Instance)
+	assert.Equal(t, map[string]any{"balance": float64(30)}, map[string]any(prob.Extensions))
+
+	resp = newTestResponse(http.StatusBadRequest, problem.ContentTypeJSON, `{"status": 409, "type": "https://example.com/probs/conflict"}`)
+	prob, err = problem.ReadResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, prob.Status)
+	assert.Equal(t, "https://example.com/probs/conflict", prob.Type)
+
+	_, err = problem.ReadResponse(newTestResponse(http.StatusBadRequest, problem.ContentTypeJSON, `{"status": "bad"`))
+	assert.ErrorContains(t, err, "unable to decode problem response")
+}
+
+func TestReadResponse_XML(t *testing.T) {
+	resp := newTestResponse(http.StatusForbidden, problem.ContentTypeXMLUTF8, `<?xml version="1.0" encoding="UTF-8"?>
+<problem xmlns="urn:ietf:rfc:7807">
+  <type>https://example.com/probs/out-of-credit</type>
+  <title>You do not have enough credit.</title>
+  <detail>Your current balance is 30, but that costs 50.</detail>
+  <balance>30</balance>
+</problem>`)
+	prob, err := problem.ReadResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, prob.Status)
+	assert.Equal(t, "https://example.com/probs/out-of-credit", prob.Type)
+	assert.Equal(t, "You do not have enough credit.", prob.Title)
+	assert.Equal(t, "Your current balance is 30, but that costs 50.", prob.Detail)
+	assert.Equal(t, map[string]any{"balance": "30"}, map[string]any(prob.Extensions))
+
+	_, err = problem.ReadResponse(newTestResponse(http.StatusBadRequest, problem.ContentTypeXML, `<problem>`))
+	assert.ErrorContains(t, err, "unable to decode problem response")
+}
+
+func TestReadResponse_NotProblem(t *testing.T) {
+	resp := newTestResponse(http.StatusBadGateway, "text/plain", "  upstream connect error  \n")
+	assert.False(t, problem.IsProblemResponse(resp))
+	prob, err := problem.ReadResponse(resp)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, prob.Status)
+	assert.Equal(t, http.StatusText(http.StatusBadGateway), prob.Title)
+	assert.Equal(t, "upstream connect error", prob.Detail)
+	assert.Empty(t, prob.UUID)
+	assert.Empty(t, prob.Stack)
+
+	prob, err = problem.ReadResponse(newTestResponse(599, "text/html", strings.Repeat("é", 300)))
+	require.NoError(t, err)
+	assert.Equal(t, 599, prob.Status)
+	assert.Equal(t, problem.DefaultTitle, prob.Title)
+	assert.Equal(t, strings.Repeat("é", 256), prob.Detail)
+
+	prob, err = problem.ReadResponse(&http.Response{StatusCode: http.StatusInternalServerError})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, prob.Status)
+	assert.Empty(t, prob.Detail)
+}
+
+func TestReadResponse_MaxBodySize(t *testing.T) {
+	body := `{"title": "Too Long", "detail": "` + strings.Repeat("x", 64) + `"}`
+
+	_, err := problem.ReadResponse(newTestResponse(http.StatusBadRequest, problem.ContentTypeJSON, body), problem.ReadOptions{
+		MaxBodySize: 32,
+	})
+	assert.ErrorIs(t, err, problem.ErrBodyTooLarge)
+
+	_, err = problem.ReadResponse(newTestResponse(http.StatusBadRequest, "text/plain", body), problem.ReadOptions{
+		MaxBodySize: 32,
+	})
+	assert.ErrorIs(t, err, problem.ErrBodyTooLarge)
+
+	prob, err := problem.ReadResponse(newTestResponse(http.StatusBadRequest, problem.ContentTypeJSON, body), problem.ReadOptions{
+		MaxBodySize: int64(len(body)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Too Long", prob.Title)
+}
+
+func TestReadResponse_Registry(t *testing.T) {
+	type account struct {
+		Balance int64 `json:"balance"`
+	}
+	var registry problem.Registry
+	problem.MustRegisterValue[account](&registry, "https://example.com/probs/out-of-credit")
+	gen := &problem.Generator{Registry: &registry}
+
+	// The registered type is decoded from the original document so large numbers keep their precision
+	prob, err := gen.ReadResponse(newTestResponse(http.StatusForbidden, problem.ContentTypeJSON, `{
+		"type": "https://example.com/probs/out-of-credit",
+		"balance": 9007199254740993
+	}`))
+	require.NoError(t, err)
+	value, ok := problem.ValueAs[account](prob)
+	require.True(t, ok)
+	assert.Equal(t, account{Balance: 9007199254740993}, value)
+}
+
+func TestReadResponse_Error(t *testing.T) {
+	prob, err := problem.ReadResponse(nil)
+	assert.Nil(t, prob)
+	assert.EqualError(t, err, "unable to read problem response: response is nil")
+
+	resp := &http.Response{Body: io.NopCloser(errReader{}), StatusCode: http.StatusBadGateway}
+	_, err = problem.ReadResponse(resp)
+	assert.ErrorContains(t, err, "unable to read problem response")
+}
+
+// errReader is an io.Reader that always fails.
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
@@ -0,0 +1,644 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"maps"
+	"net/http"
+	"net/textproto"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/jay-babu/go-problem/catalog"
+)
+
+type (
+	// generator is used to generate the source of a Go file from a catalog.Catalog.
+	generator struct {
+		// body contains the source generated so far, excluding the package clause and imports.
+		body strings.Builder
+		// catalog is the catalog.Catalog from which the source is generated.
+		catalog *catalog.Catalog
+		// imports contains the paths of all packages that must be imported by the generated source.
+		imports map[string]struct{}
+		// opts contains the options used to control generation.
+		opts options
+	}
+
+	// options contains the options used to control generation.
+	options struct {
+		// codeGen is the problem.Generator used to parse codes within the catalog.
+		codeGen *problem.Generator
+		// gen is the Go expression of the problem.Generator used to build codes within the generated source.
+		gen string
+		// out is the path of the Go file to be generated.
+		out string
+		// pkg is the name of the package of the generated source.
+		pkg string
+		// source is the path of the catalog, used for informational purposes only.
+		source string
+	}
+)
+
+const (
+	// commentWidth is the maximum width of a line containing a doc comment within the generated source, consistent with
+	// the rest of the module.
+	commentWidth = 120
+	// problemImportPath is the import path of the problem package.
+	problemImportPath = "github.com/jay-babu/go-problem"
+	// tabWidth is the width of a tab used when wrapping doc comments.
+	tabWidth = 4
+)
+
+// httpStatusNames contains the names of the net/http constants for each known HTTP client and server error status.
+var httpStatusNames = map[int]string{
+	http.StatusBadRequest:                    "StatusBadRequest",
+	http.StatusUnauthorized:                  "StatusUnauthorized",
+	http.StatusPaymentRequired:               "StatusPaymentRequired",
+	http.StatusForbidden:                     "StatusForbidden",
+	http.StatusNotFound:                      "StatusNotFound",
+	http.StatusMethodNotAllowed:              "StatusMethodNotAllowed",
+	http.StatusNotAcceptable:                 "StatusNotAcceptable",
+	http.StatusProxyAuthRequired:             "StatusProxyAuthRequired",
+	http.StatusRequestTimeout:                "StatusRequestTimeout",
+	http.StatusConflict:                      "StatusConflict",
+	http.StatusGone:                          "StatusGone",
+	http.StatusLengthRequired:                "StatusLengthRequired",
+	http.StatusPreconditionFailed:            "StatusPreconditionFailed",
+	http.StatusRequestEntityTooLarge:         "StatusRequestEntityTooLarge",
+	http.StatusRequestURITooLong:             "StatusRequestURITooLong",
+	http.StatusUnsupportedMediaType:          "StatusUnsupportedMediaType",
+	http.StatusRequestedRangeNotSatisfiable:  "StatusRequestedRangeNotSatisfiable",
+	http.StatusExpectationFailed:             "StatusExpectationFailed",
+	http.StatusTeapot:                        "StatusTeapot",
+	http.StatusMisdirectedRequest:            "StatusMisdirectedRequest",
+	http.StatusUnprocessableEntity:           "StatusUnprocessableEntity",
+	http.StatusLocked:                        "StatusLocked",
+	http.StatusFailedDependency:              "StatusFailedDependency",
+	http.StatusTooEarly:                      "StatusTooEarly",
+	http.StatusUpgradeRequired:               "StatusUpgradeRequired",
+	http.StatusPreconditionRequired:          "StatusPreconditionRequired",
+	http.StatusTooManyRequests:               "StatusTooManyRequests",
+	http.StatusRequestHeaderFieldsTooLarge:   "StatusRequestHeaderFieldsTooLarge",
+	http.StatusUnavailableForLegalReasons:    "StatusUnavailableForLegalReasons",
+	http.StatusInternalServerError:           "StatusInternalServerError",
+	http.StatusNotImplemented:                "StatusNotImplemented",
+	http.StatusBadGateway:                    "StatusBadGateway",
+	http.StatusServiceUnavailable:            "StatusServiceUnavailable",
+	http.StatusGatewayTimeout:                "StatusGatewayTimeout",
+	http.StatusHTTPVersionNotSupported:       "StatusHTTPVersionNotSupported",
+	http.StatusVariantAlsoNegotiates:         "StatusVariantAlsoNegotiates",
+	http.StatusInsufficientStorage:           "StatusInsufficientStorage",
+	http.StatusLoopDetected:                  "StatusLoopDetected",
+	http.StatusNotExtended:                   "StatusNotExtended",
+	http.StatusNetworkAuthenticationRequired: "StatusNetworkAuthenticationRequired",
+}
+
+// generate returns the formatted source of a Go file generated from the given catalog.Catalog.
+func generate(c *catalog.Catalog, opts options) ([]byte, error) {
+	g := &generator{
+		catalog: c,
+		imports: map[string]struct{}{problemImportPath: {}},
+		opts:    opts,
+	}
+	if err := g.generateTypes(); err != nil {
+		return nil, err
+	}
+	if err := g.generateDefinitions(); err != nil {
+		return nil, err
+	}
+	if err := g.generateConstructors(); err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by problemgen")
+	if opts.source != "" {
+		sb.WriteString(" from ")
+		sb.WriteString(opts.source)
+	}
+	sb.WriteString(". DO NOT EDIT.\n\npackage ")
+	sb.WriteString(opts.pkg)
+	sb.WriteString("\n\nimport (\n")
+	paths := slices.Sorted(maps.Keys(g.imports))
+	// Standard library packages are grouped before all others, consistent with goimports
+	slices.SortStableFunc(paths, func(a, b string) int {
+		return compareBool(isStdLib(b), isStdLib(a))
+	})
+	for i, path := range paths {
+		if i > 0 && isStdLib(paths[i-1]) != isStdLib(path) {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\t")
+		sb.WriteString(strconv.Quote(path))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(")\n")
+	sb.WriteString(g.body.String())
+
+	src, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to format generated source: %w", err)
+	}
+	return src, nil
+}
+
+// durationExpr returns a Go expression of the given time.Duration, using the largest unit that represents it exactly.
+func (g *generator) durationExpr(d time.Duration) string {
+	g.imports["time"] = struct{}{}
+	units := []struct {
+		name string
+		unit time.Duration
+	}{
+		{"time.Hour", time.Hour},
+		{"time.Minute", time.Minute},
+		{"time.Second", time.Second},
+		{"time.Millisecond", time.Millisecond},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", d)
+}
+
+// generateConstructors generates a constructor function for each definition within the catalog that requires one.
+func (g *generator) generateConstructors() error {
+	for _, name := range g.catalog.DefinitionNames() {
+		entry, _ := g.catalog.Entry(name)
+		if !entry.Constructor {
+			continue
+		}
+		g.imports["context"] = struct{}{}
+
+		defName := definitionVarName(name)
+		funcName := "New" + strings.TrimSuffix(name, "Definition")
+		g.body.WriteString("\n")
+		g.writeComment("", fmt.Sprintf("%s returns a problem.Problem generated from %s using the problem.Generator "+
+			"within the given context.Context, if any, otherwise problem.DefaultGenerator, along with any specified "+
+			"options.", funcName, defName))
+		if len(entry.Members) > 0 {
+			g.writeComment("", "")
+			g.writeComment("", "Each parameter, other than ctx and opts, is set as the value of the extension member it "+
+				"represents. These extensions will take precedence over any options.")
+		}
+
+		params := make([]string, 0, len(entry.Members))
+		fmt.Fprintf(&g.body, "func %s(ctx context.Context", funcName)
+		for _, member := range entry.Members {
+			param := paramName(member.Name)
+			params = append(params, param)
+			fmt.Fprintf(&g.body, ", %s %s", param, memberType(member))
+			if member.Import != "" {
+				g.imports[member.Import] = struct{}{}
+			}
+		}
+		g.body.WriteString(", opts ...problem.Option) *problem.Problem {\n")
+		if len(entry.Members) > 0 {
+			// Copy opts before appending so that the backing array of the caller's slice is never modified
+			g.body.WriteString("\topts = append(append([]problem.Option(nil), opts...),\n")
+			for i, member := range entry.Members {
+				fmt.Fprintf(&g.body, "\t\tproblem.WithExtension(%s, %s),\n", strconv.Quote(member.Name), params[i])
+			}
+			g.body.WriteString("\t)\n")
+		}
+		fmt.Fprintf(&g.body, "\treturn %s.NewContext(ctx, opts...)\n}\n", defName)
+	}
+	return nil
+}
+
+// generateDefinitions generates a variable for each definition within the catalog.
+func (g *generator) generateDefinitions() error {
+	names := g.catalog.DefinitionNames()
+	if len(names) == 0 {
+		return nil
+	}
+
+	g.body.WriteString("\nvar (")
+	for i, name := range names {
+		entry, _ := g.catalog.Entry(name)
+		varName := definitionVarName(name)
+		if !token.IsIdentifier(varName) {
+			return fmt.Errorf("definition name is not a valid Go identifier: %q", name)
+		}
+		if i > 0 {
+			g.body.WriteString("\n")
+		}
+		g.body.WriteString("\n")
+
+		doc := fmt.Sprintf("%s is a reusable problem.Definition generated from a catalog.", varName)
+		if entry.Detail != "" {
+			doc += fmt.Sprintf(" Its default detail is %q.", entry.Detail)
+		}
+		g.writeComment("\t", doc)
+		if description := entry.Docs.Description; description != "" {
+			g.writeComment("\t", "")
+			g.writeComment("\t", description)
+		}
+		if len(entry.Members) > 0 {
+			g.writeComment("\t", "")
+			g.writeComment("\t", "Problems generated from it are expected to contain the following extension members:")
+			for _, member := range entry.Members {
+				line := fmt.Sprintf("  - %s (%s)", member.Name, memberType(member))
+				if member.Description != "" {
+					line += ": " + member.Description
+				}
+				g.writeComment("\t", line)
+			}
+		}
+
+		fmt.Fprintf(&g.body, "\t%s = problem.Definition{\n", varName)
+		if entry.Code != "" {
+			parsed, err := g.opts.codeGen.ParseCode(entry.Code)
+			if err != nil {
+				return fmt.Errorf("definition %q: %w", name, err)
+			}
+			fmt.Fprintf(&g.body, "\t\tCode: %s.MustBuildCode(%d, %s),\n", g.opts.gen, parsed.Value, strconv.Quote(string(parsed.Namespace)))
+		}
+		if entry.Detail != "" {
+			fmt.Fprintf(&g.body, "\t\tDetail: %s,\n", strconv.Quote(entry.Detail))
+		}
+		if entry.DetailKey != nil {
+			lit, err := goLiteral(entry.DetailKey)
+			if err != nil {
+				return fmt.Errorf("definition %q: detailKey: %w", name, err)
+			}
+			fmt.Fprintf(&g.body, "\t\tDetailKey: %s,\n", lit)
+		}
+		if !entry.Docs.IsZero() {
+			fmt.Fprintf(&g.body, "\t\tDocs: %s,\n", docsLiteral(entry.Docs, "\t\t"))
+		}
+		if entry.Extensions != nil {
+			lit, err := goLiteral(entry.Extensions)
+			if err != nil {
+				return fmt.Errorf("definition %q: extensions: %w", name, err)
+			}
+			fmt.Fprintf(&g.body, "\t\tExtensions: %s,\n", lit)
+		}
+		if len(entry.Header) > 0 {
+			g.imports["net/http"] = struct{}{}
+			fmt.Fprintf(&g.body, "\t\tHeader: %s,\n", headerLiteral(entry.Header))
+		}
+		if entry.Instance != "" {
+			fmt.Fprintf(&g.body, "\t\tInstance: %s,\n", strconv.Quote(entry.Instance))
+		}
+		if entry.RetryAfter != 0 {
+			fmt.Fprintf(&g.body, "\t\tRetryAfter: %s,\n", g.durationExpr(entry.RetryAfter))
+		}
+		if entry.TypeRef != "" {
+			fmt.Fprintf(&g.body, "\t\tType: %s,\n", entry.TypeRef)
+		} else {
+			lit, err := g.typeLiteral(entry.Type, "\t\t")
+			if err != nil {
+				return fmt.Errorf("definition %q: type: %w", name, err)
+			}
+			fmt.Fprintf(&g.body, "\t\tType: %s,\n", lit)
+		}
+		g.body.WriteString("\t}\n")
+	}
+	g.body.WriteString(")\n")
+	return nil
+}
+
+// generateTypes generates a variable for each type within the catalog.
+func (g *generator) generateTypes() error {
+	names := g.catalog.TypeNames()
+	if len(names) == 0 {
+		return nil
+	}
+
+	g.body.WriteString("\nvar (")
+	for i, name := range names {
+		t, _ := g.catalog.Type(name)
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("type name is not a valid Go identifier: %q", name)
+		}
+		if i > 0 {
+			g.body.WriteString("\n")
+		}
+		g.body.WriteString("\n")
+
+		doc := fmt.Sprintf("%s is a reusable problem.Type generated from a catalog.", name)
+		if t.Title != "" {
+			doc += fmt.Sprintf(" Its default title is %q.", t.Title)
+		}
+		g.writeComment("\t", doc)
+		if description := t.Docs.Description; description != "" {
+			g.writeComment("\t", "")
+			g.writeComment("\t", description)
+		}
+
+		lit, err := g.typeLiteral(t, "\t")
+		if err != nil {
+			return fmt.Errorf("type %q: %w", name, err)
+		}
+		fmt.Fprintf(&g.body, "\t%s = %s\n", name, lit)
+	}
+	g.body.WriteString(")\n")
+	return nil
+}
+
+// typeLiteral returns a Go composite literal of the given problem.Type, where indent is the indentation of the line on
+// which it starts.
+func (g *generator) typeLiteral(t problem.Type, indent string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("problem.Type{\n")
+	if !t.Docs.IsZero() {
+		fmt.Fprintf(&sb, "%s\tDocs: %s,\n", indent, docsLiteral(t.Docs, indent+"\t"))
+	}
+	if t.LogLevel != 0 {
+		fmt.Fprintf(&sb, "%s\tLogLevel: %s,\n", indent, logLevelExpr(t.LogLevel))
+	}
+	if t.RetryAfter != 0 {
+		fmt.Fprintf(&sb, "%s\tRetryAfter: %s,\n", indent, g.durationExpr(t.RetryAfter))
+	}
+	if t.Retryable {
+		fmt.Fprintf(&sb, "%s\tRetryable: true,\n", indent)
+	}
+	statusExpr := strconv.Itoa(t.Status)
+	if name, found := httpStatusNames[t.Status]; found {
+		g.imports["net/http"] = struct{}{}
+		statusExpr = "http." + name
+	}
+	if t.Status != 0 {
+		fmt.Fprintf(&sb, "%s\tStatus: %s,\n", indent, statusExpr)
+	}
+	if t.Title != "" {
+		if _, found := httpStatusNames[t.Status]; found && t.Title == http.StatusText(t.Status) {
+			fmt.Fprintf(&sb, "%s\tTitle: http.StatusText(%s),\n", indent, statusExpr)
+		} else {
+			fmt.Fprintf(&sb, "%s\tTitle: %s,\n", indent, strconv.Quote(t.Title))
+		}
+	}
+	if t.TitleKey != nil {
+		lit, err := goLiteral(t.TitleKey)
+		if err != nil {
+			return "", fmt.Errorf("titleKey: %w", err)
+		}
+		fmt.Fprintf(&sb, "%s\tTitleKey: %s,\n", indent, lit)
+	}
+	if t.URI != "" {
+		fmt.Fprintf(&sb, "%s\tURI: %s,\n", indent, strconv.Quote(t.URI))
+	}
+	sb.WriteString(indent)
+	sb.WriteString("}")
+	return sb.String(), nil
+}
+
+// writeComment writes the given text as a line comment, wrapped so that no line exceeds commentWidth where possible,
+// where indent is the indentation of each line. An empty text results in an empty comment line.
+//
+// Lines starting with whitespace (e.g. list items) are not joined with any preceding line, however, continuation lines
+// are indented to align with the item text.
+func (g *generator) writeComment(indent, text string) {
+	prefix := indent + "// "
+	width := commentWidth - (len(prefix) + strings.Count(indent, "\t")*(tabWidth-1))
+	if text == "" {
+		g.body.WriteString(strings.TrimRight(prefix, " "))
+		g.body.WriteString("\n")
+		return
+	}
+
+	var continuation string
+	if trimmed := strings.TrimLeft(text, " "); trimmed != text && strings.HasPrefix(trimmed, "- ") {
+		continuation = strings.Repeat(" ", len(text)-len(trimmed)+2)
+	}
+	line := ""
+	for i, word := range strings.Fields(text) {
+		if i == 0 && continuation != "" {
+			word = continuation[:len(continuation)-2] + word
+		}
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) > width:
+			g.body.WriteString(prefix + line + "\n")
+			line = continuation + word
+		default:
+			line += " " + word
+		}
+	}
+	g.body.WriteString(prefix + line + "\n")
+}
+
+// compareBool compares two booleans where false is ordered before true.
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// definitionVarName returns the name of the variable for the definition with the given name.
+func definitionVarName(name string) string {
+	if strings.HasSuffix(name, "Definition") {
+		return name
+	}
+	return name + "Definition"
+}
+
+// docsLiteral returns a Go composite literal of the given problem.Documentation, where indent is the indentation of the
+// line on which it starts.
+func docsLiteral(d problem.Documentation, indent string) string {
+	var sb strings.Builder
+	sb.WriteString("problem.Documentation{\n")
+	if d.Description != "" {
+		fmt.Fprintf(&sb, "%s\tDescription: %s,\n", indent, strconv.Quote(d.Description))
+	}
+	if len(d.Links) > 0 {
+		fmt.Fprintf(&sb, "%s\tLinks: []problem.Link{\n", indent)
+		for _, link := range d.Links {
+			fmt.Fprintf(&sb, "%s\t\t{Title: %s, URL: %s},\n", indent, strconv.Quote(link.Title), strconv.Quote(link.URL))
+		}
+		fmt.Fprintf(&sb, "%s\t},\n", indent)
+	}
+	if d.Owner != "" {
+		fmt.Fprintf(&sb, "%s\tOwner: %s,\n", indent, strconv.Quote(d.Owner))
+	}
+	if len(d.Resolution) > 0 {
+		fmt.Fprintf(&sb, "%s\tResolution: %s,\n", indent, stringSliceLiteral(d.Resolution))
+	}
+	if len(d.Tags) > 0 {
+		fmt.Fprintf(&sb, "%s\tTags: %s,\n", indent, stringSliceLiteral(d.Tags))
+	}
+	sb.WriteString(indent)
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// goLiteral returns a Go expression of the given value, which is expected to have been decoded from YAML or JSON.
+//
+// An error is returned if the value is of an unsupported type.
+func goLiteral(v any) (string, error) {
+	switch _v := v.(type) {
+	case nil:
+		return "nil", nil
+	case bool:
+		return strconv.FormatBool(_v), nil
+	case string:
+		return strconv.Quote(_v), nil
+	case int:
+		return strconv.Itoa(_v), nil
+	case int64:
+		return strconv.FormatInt(_v, 10), nil
+	case uint64:
+		return strconv.FormatUint(_v, 10), nil
+	case float64:
+		return strconv.FormatFloat(_v, 'g', -1, 64), nil
+	case []any:
+		items := make([]string, len(_v))
+		for i, item := range _v {
+			lit, err := goLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = lit
+		}
+		return "[]any{" + strings.Join(items, ", ") + "}", nil
+	case map[string]any:
+		var sb strings.Builder
+		sb.WriteString("map[string]any{")
+		for i, k := range slices.Sorted(maps.Keys(_v)) {
+			lit, err := goLiteral(_v[k])
+			if err != nil {
+				return "", err
+			}
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(strconv.Quote(k))
+			sb.WriteString(": ")
+			sb.WriteString(lit)
+		}
+		sb.WriteString("}")
+		return sb.String(), nil
+	case problem.Extensions:
+		return goLiteral(map[string]any(_v))
+	default:
+		return "", fmt.Errorf("unsupported value type: %T", v)
+	}
+}
+
+// headerLiteral returns a Go composite literal of the given http.Header, with each key canonicalized.
+func headerLiteral(header http.Header) string {
+	canonical := make(http.Header, len(header))
+	for k, vs := range header {
+		k = textproto.CanonicalMIMEHeaderKey(k)
+		canonical[k] = append(canonical[k], vs...)
+	}
+	var sb strings.Builder
+	sb.WriteString("http.Header{")
+	for i, k := range slices.Sorted(maps.Keys(canonical)) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strconv.Quote(k))
+		sb.WriteString(": ")
+		sb.WriteString("{" + quotedList(canonical[k]) + "}")
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// isStdLib returns whether the given import path is that of a package within the standard library, based on whether its
+// first element contains a dot.
+func isStdLib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// logLevelExpr returns a Go expression of the given problem.LogLevel.
+func logLevelExpr(level problem.LogLevel) string {
+	switch level {
+	case problem.LogLevelDebug:
+		return "problem.LogLevelDebug"
+	case problem.LogLevelInfo:
+		return "problem.LogLevelInfo"
+	case problem.LogLevelWarn:
+		return "problem.LogLevelWarn"
+	case problem.LogLevelError:
+		return "problem.LogLevelError"
+	default:
+		return fmt.Sprintf("problem.LogLevel(%d)", level)
+	}
+}
+
+// memberType returns the Go type expression of the given catalog.Member.
+func memberType(member catalog.Member) string {
+	if member.Type == "" {
+		return "any"
+	}
+	return member.Type
+}
+
+// paramName returns a valid Go identifier, in lower camel case, to be used as the name of the parameter for the
+// extension member with the given name.
+func paramName(name string) string {
+	var sb strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_':
+			upper = sb.Len() > 0
+		case sb.Len() == 0:
+			if unicode.IsDigit(r) {
+				sb.WriteRune('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+		case upper:
+			sb.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	param := sb.String()
+	if param == "" || token.IsKeyword(param) || param == "ctx" || param == "opts" {
+		param += "Value"
+	}
+	return param
+}
+
+// quotedList returns a comma-separated list of the given strings as Go string literals.
+func quotedList(values []string) string {
+	items := make([]string, len(values))
+	for i, value := range values {
+		items[i] = strconv.Quote(value)
+	}
+	return strings.Join(items, ", ")
+}
+
+// stringSliceLiteral returns a Go composite literal of the given strings.
+func stringSliceLiteral(values []string) string {
+	return "[]string{" + quotedList(values) + "}"
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/jay-babu/go-problem/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	c, err := catalog.Loader{}.Load(catalog.File{
+		Types: map[string]problem.Type{
+			"NotFound": {
+				Docs: problem.Documentation{
+					Description: "Indicates that the requested resource does not exist.",
+					Links:       []problem.Link{{Title: "Runbook", URL: "https://example.com/runbooks/not-found"}},
+					Owner:       "platform",
+					Tags:        []string{"http"},
+				},
+				LogLevel: problem.LogLevelWarn,
+				Status:   404,
+				Title:    "Not Found",
+			},
+		},
+		Definitions: map[string]catalog.DefinitionEntry{
+			"UserNotFound": {
+				Definition:  problem.Definition{Code: "USER-404", Header: http.Header{"cache-control": {"no-store"}}},
+				Constructor: true,
+				Members:     []catalog.Member{{Name: "user-id", Type: "string"}, {Name: "type_", Type: "int"}},
+				TypeRef:     "NotFound",
+			},
+			"OutOfCredit": {
+				Definition: problem.Definition{
+					Extensions: map[string]any{"balance": 0, "accounts": []any{"a"}},
+					RetryAfter: 90 * time.Second,
+					Type:       problem.Type{Retryable: true, Status: 403, Title: "You do not have enough credit."},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	src, err := generate(c, options{
+		codeGen: problem.DefaultGenerator,
+		gen:     "problem.DefaultGenerator",
+		pkg:     "probs",
+	})
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "problems_gen.go", src, parser.AllErrors)
+	require.NoError(t, err)
+
+	out := string(src)
+	assert.Contains(t, out, "// Code generated by problemgen. DO NOT EDIT.")
+	assert.Contains(t, out, "Title:    http.StatusText(http.StatusNotFound),")
+	assert.Contains(t, out, "\t// Indicates that the requested resource does not exist.\n")
+	assert.Contains(t, out, `{Title: "Runbook", URL: "https://example.com/runbooks/not-found"},`)
+	assert.Contains(t, out, `Owner: "platform",`)
+	assert.Contains(t, out, `Tags:  []string{"http"},`)
+	assert.Contains(t, out, `Code:   problem.DefaultGenerator.MustBuildCode(404, "USER"),`)
+	assert.Contains(t, out, `Extensions: map[string]any{"accounts": []any{"a"}, "balance": 0},`)
+	assert.Contains(t, out, `Title:     "You do not have enough credit.",`)
+	assert.Contains(t, out, "RetryAfter: 90 * time.Second,")
+	assert.Contains(t, out, `Header: http.Header{"Cache-Control": {"no-store"}},`)
+	assert.Contains(t, out, "Retryable: true,")
+	assert.Contains(t, out, "func NewUserNotFound(ctx context.Context, userId string, type_ int, opts ...problem.Option) *problem.Problem {")
+	assert.Contains(t, out, "opts = append(append([]problem.Option(nil), opts...),\n"+
+		"\t\tproblem.WithExtension(\"user-id\", userId),\n"+
+		"\t\tproblem.WithExtension(\"type_\", type_),\n"+
+		"\t)\n")
+	assert.Contains(t, out, "return UserNotFoundDefinition.NewContext(ctx, opts...)")
+}
+
+func TestParamName(t *testing.T) {
+	testCases := map[string]string{
+		"userId":    "userId",
+		"UserID":    "userID",
+		"user-id":   "userId",
+		"user id 2": "userId2",
+		"2fa":       "_2fa",
+		"func":      "funcValue",
+		"ctx":       "ctxValue",
+		"---":       "Value",
+	}
+	for name, expected := range testCases {
+		assert.Equal(t, expected, paramName(name), name)
+	}
+}
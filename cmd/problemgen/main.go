@@ -0,0 +1,112 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Command problemgen generates a Go file containing problem.Type and problem.Definition variables, and optionally
+// constructor functions, from a catalog of types and definitions (see the catalog package for more information).
+//
+// It is intended to be used with go:generate. For example;
+//
+//	//go:generate go run github.com/jay-babu/go-problem/cmd/problemgen -out problems_gen.go problems.yaml
+//
+// The catalog may be a single YAML/JSON file or a directory containing any number of them.
+//
+// Usage:
+//
+//	problemgen [flags] catalog
+//
+// The flags are:
+//
+//	-gen string
+//	    expression of the problem.Generator used to build codes (default "problem.DefaultGenerator")
+//	-out string
+//	    path of the Go file to be generated (default "problems_gen.go")
+//	-pkg string
+//	    name of the package of the generated Go file (default $GOPACKAGE)
+//	-sep string
+//	    separator used to parse codes within the catalog (default "-")
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/jay-babu/go-problem/catalog"
+)
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.gen, "gen", "problem.DefaultGenerator", "expression of the problem.Generator used to build codes")
+	flag.StringVar(&opts.out, "out", "problems_gen.go", "path of the Go file to be generated")
+	flag.StringVar(&opts.pkg, "pkg", os.Getenv("GOPACKAGE"), "name of the package of the generated Go file (default $GOPACKAGE)")
+	sep := flag.String("sep", string(problem.DefaultCodeSeparator), "separator used to parse codes within the catalog")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage: problemgen [flags] catalog\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), *sep, opts); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "problemgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run loads the catalog at the given path, using the code separator provided, and generates a Go file from it.
+func run(path, sep string, opts options) error {
+	if opts.pkg == "" {
+		return fmt.Errorf("package name must be provided using -pkg when not run by go generate")
+	}
+	r, size := utf8.DecodeRuneInString(sep)
+	if size == 0 || size != len(sep) {
+		return fmt.Errorf("invalid code separator: %q", sep)
+	}
+	opts.codeGen = &problem.Generator{CodeSeparator: r}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	loader := catalog.Loader{Generator: opts.codeGen}
+	var c *catalog.Catalog
+	if info.IsDir() {
+		c, err = loader.LoadFS(os.DirFS(path))
+	} else {
+		c, err = loader.LoadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	opts.source = filepath.ToSlash(path)
+	src, err := generate(c, opts)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(opts.out, src, fs.FileMode(0o644))
+}
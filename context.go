@@ -26,8 +26,18 @@ import "context"
 // packages.
 type contextKey uint
 
-// contextKeyGenerator is the key associated with a Generator within a context.Context.
-const contextKeyGenerator contextKey = 0
+const (
+	// contextKeyGenerator is the key associated with a Generator within a context.Context.
+	contextKeyGenerator contextKey = iota
+	// contextKeyLanguage is the key associated with a language tag within a context.Context.
+	contextKeyLanguage
+	// contextKeyTranslationExtensions is the key associated with the extensions of a Problem being localized within a
+	// context.Context.
+	contextKeyTranslationExtensions
+	// contextKeyResponseStatus is the key associated with the status code of an HTTP response to which a Problem is
+	// being written within a context.Context.
+	contextKeyResponseStatus
+)
 
 // GetGenerator returns the Generator within the given context.Context, otherwise DefaultGenerator.
 func GetGenerator(ctx context.Context) *Generator {
@@ -37,6 +47,39 @@ func GetGenerator(ctx context.Context) *Generator {
 	return DefaultGenerator
 }
 
+// GetLanguage returns the language tag within the given context.Context, if any, otherwise an empty string.
+//
+// A Translator should use the language tag, where present, to decide which language a translation key is to be
+// localized into, since it is how the language negotiated when writing a Problem to an HTTP response is passed to it
+// (see Generator.Languages for more information).
+func GetLanguage(ctx context.Context) string {
+	lang, _ := ctx.Value(contextKeyLanguage).(string)
+	return lang
+}
+
+// GetResponseStatus returns the status code of the HTTP response to which a Problem is being written within the given
+// context.Context, if any, otherwise zero.
+//
+// The status code is only present within the context.Context passed to a Redactor when redacting a Problem being
+// written to an HTTP response, and may differ from Problem.Status where it has been overridden using
+// WriteOptions.Status. A Redactor should use it, where present, to decide how a Problem is to be redacted.
+func GetResponseStatus(ctx context.Context) int {
+	status, _ := ctx.Value(contextKeyResponseStatus).(int)
+	return status
+}
+
+// GetTranslationExtensions returns the extensions of the Problem being localized within the given context.Context, if
+// any, otherwise nil.
+//
+// The extensions are only present within the context.Context passed to a Translator when localizing the title or
+// detail of a Problem, either during its construction or when it is localized again (see Generator.Localize for more
+// information), allowing a Translator to fill any placeholders within a localized value using them. The returned map
+// must not be modified.
+func GetTranslationExtensions(ctx context.Context) map[string]any {
+	extensions, _ := ctx.Value(contextKeyTranslationExtensions).(map[string]any)
+	return extensions
+}
+
 // UsingGenerator returns a copy of the given parent context.Context containing the Generator provided.
 //
 // If gen is nil, DefaultGenerator is used.
@@ -46,3 +89,31 @@ func UsingGenerator(parent context.Context, gen *Generator) context.Context {
 	}
 	return context.WithValue(parent, contextKeyGenerator, gen)
 }
+
+// UsingLanguage returns a copy of the given parent context.Context containing the language tag provided (e.g. "en" or
+// "fr-CA").
+//
+// When present within the context.Context of an HTTP request, the language tag takes precedence over any language that
+// would otherwise be negotiated using the Accept-Language header of the request when writing a Problem to an HTTP
+// response. See Generator.Languages for more information.
+func UsingLanguage(parent context.Context, lang string) context.Context {
+	return context.WithValue(parent, contextKeyLanguage, lang)
+}
+
+// usingResponseStatus returns a copy of the given parent context.Context containing the HTTP response status code
+// provided, which can be retrieved using GetResponseStatus. If status is zero, parent is returned unchanged.
+func usingResponseStatus(parent context.Context, status int) context.Context {
+	if status == 0 {
+		return parent
+	}
+	return context.WithValue(parent, contextKeyResponseStatus, status)
+}
+
+// usingTranslationExtensions returns a copy of the given parent context.Context containing the extensions provided,
+// which can be retrieved using GetTranslationExtensions. If extensions is empty, parent is returned unchanged.
+func usingTranslationExtensions(parent context.Context, extensions map[string]any) context.Context {
+	if len(extensions) == 0 {
+		return parent
+	}
+	return context.WithValue(parent, contextKeyTranslationExtensions, extensions)
+}
@@ -22,6 +22,8 @@ package problem
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/neocotic/go-optional"
 )
@@ -52,11 +54,21 @@ type Definition struct {
 	//
 	// If DetailKey is empty it, it is ignored.
 	DetailKey any `json:"detailKey" xml:"detailKey" yaml:"detailKey"`
+	// Docs contains optional human-readable documentation for the Definition, which is never included when a Problem
+	// generated from the Definition is serialized.
+	//
+	// Definition.Documentation can be used to combine Docs with that of Type.
+	Docs Documentation `json:"docs,omitempty" xml:"docs,omitempty" yaml:"docs,omitempty"`
 	// Extensions is the default extensions to be assigned to a Problem generated from the Definition. See
 	// Problem.Extensions for more information.
 	//
 	// If Extensions is nil, no default is used.
 	Extensions map[string]any `json:"extensions" xml:"extensions" yaml:"extensions"`
+	// Header contains the default HTTP response headers to be written along with a Problem generated from the
+	// Definition (e.g. "WWW-Authenticate" or "Cache-Control"). See Problem.Header for more information.
+	//
+	// If Header is empty, no default is used.
+	Header http.Header `json:"header,omitempty" xml:"-" yaml:"header,omitempty"`
 	// Instance is the default instance URI to be assigned to a Problem generated from the Definition. See
 	// Problem.Instance for more information.
 	//
@@ -64,6 +76,11 @@ type Definition struct {
 	//
 	// If Instance is empty, no default is used.
 	Instance string `json:"instance" xml:"instance" yaml:"instance"`
+	// RetryAfter is the default delay after which the request that resulted in a Problem generated from the Definition
+	// may be retried. See Problem.RetryAfter for more information.
+	//
+	// If RetryAfter is less than or equal to zero, Type.RetryAfter is used.
+	RetryAfter time.Duration `json:"retryAfter,omitempty" xml:"retryAfter,omitempty" yaml:"retryAfter,omitempty"`
 	// Type contains fields defining the type of Problem generated from the Definition, typically containing additional
 	// default values.
 	Type Type `json:"type" xml:"type" yaml:"type"`
@@ -109,6 +126,12 @@ func (d Definition) BuildUsing(gen *Generator) *Builder {
 	}
 }
 
+// Documentation returns the Documentation of the Definition combined with that of its Type, where the fields of
+// Definition.Docs take precedence. See Documentation.Merge for more information.
+func (d Definition) Documentation() Documentation {
+	return d.Docs.Merge(d.Type.Docs)
+}
+
 // New is a convenient shorthand for calling Generator.New on DefaultGenerator, including FromDefinition with the
 // Definition along with any specified options.
 func (d Definition) New(opts ...Option) *Problem {
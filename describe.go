@@ -0,0 +1,267 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// The following interfaces may be implemented by any error to describe how it is to be represented as a Problem,
+// allowing packages to control how their errors are rendered without importing this module. Other than Describer, the
+// methods intentionally only use built-in types.
+//
+// They are honored by As, and everything that relies on it (e.g. Generator.WriteError, the Middleware functions, and
+// any Unwrapper), for any error in the tree of an error. If an error implements Describer and returns a non-nil
+// Problem, it is used as is. Otherwise, if an error implements any of the other interfaces, a Problem is constructed
+// that wraps the error and contains only the fields described, along with the default status and title where not
+// described, where the title defaults to the text of any status described (see http.StatusText). It is constructed
+// using the Generator in use, where known (e.g. by Generator.WriteError), otherwise DefaultGenerator, and contains
+// neither a stack trace nor "UUID" so that the same error is always described by an equal Problem.
+//
+// For example;
+//
+//	type OutOfCreditError struct {
+//		Balance int
+//	}
+//
+//	func (e *OutOfCreditError) Error() string          { return "not enough credit" }
+//	func (e *OutOfCreditError) ProblemStatus() int     { return http.StatusForbidden }
+//	func (e *OutOfCreditError) ProblemType() string    { return "https://example.com/probs/out-of-credit" }
+//	func (e *OutOfCreditError) ProblemExtensions() map[string]any {
+//		return map[string]any{"balance": e.Balance}
+//	}
+type (
+	// CodeDescriber may be implemented by an error to describe the Problem.Code of its Problem representation. The
+	// returned code is used as is without being validated.
+	CodeDescriber interface {
+		ProblemCode() string
+	}
+
+	// Describer may be implemented by an error to return its complete Problem representation, which would typically
+	// wrap the error itself.
+	//
+	// Describer.Problem may wrap the error itself (e.g. using Wrap), as Describer.Problem is never called again for an
+	// error while it is already being called for an equal error, which would otherwise result in infinite recursion.
+	// Instead, any other interfaces implemented by the error are used, and the same applies if Describer.Problem
+	// returns nil. Since calls in progress are tracked by the equality of errors, rather than per goroutine, this also
+	// applies while Describer.Problem is being called for an equal error on another goroutine.
+	Describer interface {
+		Problem() *Problem
+	}
+
+	// DetailDescriber may be implemented by an error to describe the Problem.Detail of its Problem representation.
+	DetailDescriber interface {
+		ProblemDetail() string
+	}
+
+	// ExtensionsDescriber may be implemented by an error to describe the Problem.Extensions of its Problem
+	// representation. Any extensions with a key that is either empty or reserved (i.e. conflicts with Problem-level
+	// fields) are ignored.
+	ExtensionsDescriber interface {
+		ProblemExtensions() map[string]any
+	}
+
+	// StatusDescriber may be implemented by an error to describe the Problem.Status of its Problem representation.
+	StatusDescriber interface {
+		ProblemStatus() int
+	}
+
+	// TitleDescriber may be implemented by an error to describe the Problem.Title of its Problem representation.
+	TitleDescriber interface {
+		ProblemTitle() string
+	}
+
+	// TypeDescriber may be implemented by an error to describe the Problem.Type of its Problem representation.
+	TypeDescriber interface {
+		ProblemType() string
+	}
+)
+
+// describe returns the Problem representation of the given error if it implements any of the describer interfaces
+// (e.g. Describer or StatusDescriber). err itself is checked, not its tree.
+//
+// Unless err implements Describer, the Problem is constructed using the given Generator, if not nil, otherwise
+// DefaultGenerator, without a stack trace or "UUID".
+func describe(gen *Generator, err error) (*Problem, bool) {
+	if d, ok := err.(Describer); ok {
+		if p := callDescriber(d, err); p != nil {
+			return p, true
+		}
+	}
+
+	var (
+		described bool
+		opts      []Option
+	)
+	if d, ok := err.(CodeDescriber); ok {
+		code := Code(d.ProblemCode())
+		opts, described = append(opts, func(b *Builder) { b.code = code }), true
+	}
+	if d, ok := err.(DetailDescriber); ok {
+		opts, described = append(opts, WithDetail(d.ProblemDetail())), true
+	}
+	if d, ok := err.(ExtensionsDescriber); ok {
+		for k, v := range d.ProblemExtensions() {
+			if validationExtensionKey(k) == nil {
+				opts = append(opts, WithExtension(k, v))
+			}
+		}
+		described = true
+	}
+	var status int
+	if d, ok := err.(StatusDescriber); ok {
+		status = d.ProblemStatus()
+		opts, described = append(opts, WithStatus(status)), true
+	}
+	if d, ok := err.(TitleDescriber); ok {
+		opts, described = append(opts, WithTitle(d.ProblemTitle())), true
+	} else if title := http.StatusText(status); title != "" {
+		opts = append(opts, WithTitle(title))
+	}
+	if d, ok := err.(TypeDescriber); ok {
+		opts, described = append(opts, WithType(d.ProblemType())), true
+	}
+	if !described {
+		return nil, false
+	}
+
+	if gen == nil {
+		gen = DefaultGenerator
+	}
+	b := gen.Build().Stack(FlagDisable).UUID(FlagDisable)
+	for _, opt := range opts {
+		opt(b)
+	}
+	p := b.build(1)
+	// Set directly as Builder.Wrap would result in infinite recursion
+	p.errs = []error{err}
+	return p, true
+}
+
+// as is equivalent to As, however, any Problem described by an error (see Describer) is constructed using the
+// Generator.
+func (g *Generator) as(err error) (*Problem, bool) {
+	if err == nil {
+		return nil, false
+	}
+	return findProblem(g, err, nil)
+}
+
+// describing contains the keys (see describerKey) of the errors for which Describer.Problem is being called, along with
+// the number of calls in progress, in order to guard against infinite recursion.
+var describing = struct {
+	mu    sync.Mutex
+	calls map[any]int
+}{calls: make(map[any]int)}
+
+// callDescriber returns the result of calling Describer.Problem on the given Describer, which is err itself, unless it
+// is already being called for an equal error (e.g. when Describer.Problem wraps the error itself), in which case nil
+// is returned.
+func callDescriber(d Describer, err error) *Problem {
+	key := describerKey(err)
+	describing.mu.Lock()
+	if describing.calls[key] > 0 {
+		describing.mu.Unlock()
+		return nil
+	}
+	describing.calls[key]++
+	describing.mu.Unlock()
+
+	defer func() {
+		describing.mu.Lock()
+		if describing.calls[key]--; describing.calls[key] == 0 {
+			delete(describing.calls, key)
+		}
+		describing.mu.Unlock()
+	}()
+	return d.Problem()
+}
+
+// isDescribing returns whether Describer.Problem is being called for an error equal to the one provided.
+func isDescribing(err error) bool {
+	if _, ok := err.(Describer); !ok {
+		return false
+	}
+	key := describerKey(err)
+	describing.mu.Lock()
+	defer describing.mu.Unlock()
+	return describing.calls[key] > 0
+}
+
+// describerKey returns the key used to track calls to Describer.Problem for the given error, which is err itself if
+// it is comparable, otherwise its type.
+func describerKey(err error) any {
+	if t := reflect.TypeOf(err); !t.Comparable() {
+		return t
+	}
+	return err
+}
+
+// findProblem walks the tree of the given error in the same order as errors.As, returning the first Problem found,
+// whether it is an error in the tree itself or described by one (see Describer), for which match returns true. If
+// match is nil, the first Problem found is returned.
+//
+// Any Problem described by an error is constructed using the given Generator, if not nil, otherwise DefaultGenerator.
+//
+// Unlike errors.As, when a Problem is found that does not match, only its wrapped errors are walked, rather than the
+// tree of any Problem described by an error, which may wrap the error itself.
+func findProblem(gen *Generator, err error, match func(p *Problem) bool) (*Problem, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var (
+		p     *Problem
+		found bool
+	)
+	switch e := err.(type) {
+	case *Problem:
+		p, found = e, true
+	case interface{ As(any) bool }:
+		var target *Problem
+		if found = e.As(&target); found {
+			p = target
+		}
+	}
+	if !found {
+		p, found = describe(gen, err)
+	}
+	if found && (match == nil || match(p)) {
+		return p, true
+	}
+	if e, isProblem := err.(*Problem); isProblem && e == nil {
+		return nil, false
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return findProblem(gen, e.Unwrap(), match)
+	case interface{ Unwrap() []error }:
+		for _, child := range e.Unwrap() {
+			if p, found = findProblem(gen, child, match); found {
+				return p, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type describedError struct {
+	balance int
+}
+
+func (e *describedError) Error() string       { return "not enough credit" }
+func (e *describedError) ProblemCode() string { return "CREDIT-1" }
+func (e *describedError) ProblemDetail() string {
+	return fmt.Sprintf("Your current balance is %d", e.balance)
+}
+func (e *describedError) ProblemExtensions() map[string]any {
+	return map[string]any{"balance": e.balance, "status": "ignored"}
+}
+func (e *describedError) ProblemStatus() int   { return http.StatusForbidden }
+func (e *describedError) ProblemTitle() string { return "You do not have enough credit." }
+func (e *describedError) ProblemType() string  { return "https://example.com/probs/out-of-credit" }
+
+type selfDescribingError struct{}
+
+func (e selfDescribingError) Error() string { return "locked" }
+func (e selfDescribingError) Problem() *problem.Problem {
+	// Wrapping itself must not result in infinite recursion
+	return problem.New(problem.WithStatus(http.StatusLocked), problem.Wrap(e), problem.WithExtension("self", true))
+}
+
+type lockedError struct{}
+
+func (e lockedError) Error() string { return "locked" }
+func (e lockedError) Problem() *problem.Problem {
+	return problem.New(
+		problem.WithStatus(http.StatusLocked),
+		problem.WithLogLevel(problem.LogLevelWarn),
+		problem.Wrap(e, problem.FullUnwrapper()),
+	)
+}
+
+type statusError int
+
+func (e statusError) Error() string      { return http.StatusText(int(e)) }
+func (e statusError) ProblemStatus() int { return int(e) }
+
+func TestAs_Describer(t *testing.T) {
+	err := fmt.Errorf("charge: %w", &describedError{balance: 30})
+	prob, isProblem := problem.As(err)
+	require.True(t, isProblem)
+	assert.Equal(t, problem.Code("CREDIT-1"), prob.Code)
+	assert.Equal(t, "Your current balance is 30", prob.Detail)
+	assert.Equal(t, problem.Extensions{"balance": 30}, prob.Extensions)
+	assert.Equal(t, http.StatusForbidden, prob.Status)
+	assert.Equal(t, "You do not have enough credit.", prob.Title)
+	assert.Equal(t, "https://example.com/probs/out-of-credit", prob.Type)
+	assert.ErrorIs(t, prob, err.(interface{ Unwrap() error }).Unwrap())
+
+	prob, isProblem = problem.As(errors.Join(errors.New("other"), statusError(http.StatusConflict)))
+	require.True(t, isProblem)
+	assert.Equal(t, http.StatusConflict, prob.Status)
+	assert.Equal(t, "Conflict", prob.Title)
+
+	_, isProblem = problem.As(errors.New("plain"))
+	assert.False(t, isProblem)
+}
+
+func TestAs_DescriberTitle(t *testing.T) {
+	prob, isProblem := problem.As(statusError(http.StatusNotFound))
+	require.True(t, isProblem)
+	assert.Equal(t, "Not Found", prob.Title)
+
+	prob, isProblem = problem.As(statusError(599))
+	require.True(t, isProblem)
+	assert.Equal(t, problem.DefaultTitle, prob.Title)
+}
+
+func BenchmarkAs_NotDescribed(b *testing.B) {
+	err := fmt.Errorf("outer: %w", fmt.Errorf("middle: %w", errors.New("plain")))
+	b.ReportAllocs()
+	for b.Loop() {
+		problem.As(err)
+	}
+}
+
+func TestAs_DescriberSelf(t *testing.T) {
+	prob, isProblem := problem.As(fmt.Errorf("update: %w", selfDescribingError{}))
+	require.True(t, isProblem)
+	assert.Equal(t, http.StatusLocked, prob.Status)
+	assert.Equal(t, true, prob.Extensions["self"])
+	assert.ErrorIs(t, prob, selfDescribingError{})
+}
+
+func TestAs_DescriberStable(t *testing.T) {
+	err := &describedError{balance: 30}
+	first, isProblem := problem.As(err)
+	require.True(t, isProblem)
+	second, isProblem := problem.As(err)
+	require.True(t, isProblem)
+	assert.Empty(t, first.UUID)
+	assert.Empty(t, first.Stack)
+	assert.Equal(t, first, second)
+}
+
+func TestAs_DescriberSelfJoined(t *testing.T) {
+	gen := &problem.Generator{Mapper: problem.NewMapper(problem.MapFunc(func(error) bool {
+		return true
+	}, problem.Definition{Type: problem.Type{Status: http.StatusTeapot}}))}
+	prob := gen.New(problem.Wrap(errors.Join(errors.New("other"), selfDescribingError{})))
+	assert.Equal(t, http.StatusInternalServerError, prob.Status)
+	assert.ErrorIs(t, prob, selfDescribingError{})
+
+	prob, isProblem := problem.As(errors.Join(errors.New("other"), selfDescribingError{}))
+	require.True(t, isProblem)
+	assert.Equal(t, http.StatusLocked, prob.Status)
+}
+
+func TestUnwrapper_DescriberDirect(t *testing.T) {
+	prob := problem.New(problem.Wrap(lockedError{}, problem.FullUnwrapper()))
+	assert.Equal(t, http.StatusLocked, prob.Status)
+	assert.Equal(t, problem.LogLevelWarn, prob.LogInfo().Level)
+	assert.ErrorIs(t, prob, lockedError{})
+
+	prob = problem.New(problem.Wrap(lockedError{}, problem.PropagatedFieldUnwrapper()))
+	assert.Equal(t, http.StatusInternalServerError, prob.Status)
+	assert.Equal(t, problem.LogLevelWarn, prob.LogInfo().Level)
+
+	prob = problem.New(problem.Wrap(selfDescribingError{}, problem.FullUnwrapper()))
+	assert.Equal(t, http.StatusLocked, prob.Status)
+	assert.Equal(t, true, prob.Extensions["self"])
+}
+
+func TestAsMatch_Describer(t *testing.T) {
+	err := fmt.Errorf("outer: %w", fmt.Errorf("%w: %w", statusError(http.StatusConflict), statusError(http.StatusGone)))
+	prob, isMatch := problem.AsMatch(err, problem.HasStatus(http.StatusGone))
+	require.True(t, isMatch)
+	assert.Equal(t, http.StatusGone, prob.Status)
+
+	_, isMatch = problem.AsMatch(err, problem.HasStatus(http.StatusTeapot))
+	assert.False(t, isMatch)
+}
+
+func TestUnwrapper_Describer(t *testing.T) {
+	gen := &problem.Generator{Unwrapper: problem.FullUnwrapper()}
+	prob := gen.New(problem.Wrap(fmt.Errorf("charge: %w", &describedError{balance: 30})))
+	assert.Equal(t, http.StatusForbidden, prob.Status)
+	assert.Equal(t, "Your current balance is 30", prob.Detail)
+}
+
+func TestWriteError_Describer(t *testing.T) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	err := problem.WriteError(&describedError{balance: 30}, w, req, func(err error) *problem.Problem {
+		t.Fatal("probFunc must not be called for describing errors")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, float64(30), body["balance"])
+	assert.Equal(t, "CREDIT-1", body["code"])
+}
+
+func TestWriteError_DescriberGenerator(t *testing.T) {
+	gen := &problem.Generator{Typer: func(problem.Type) string {
+		return "https://example.com/probs/typed"
+	}}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, gen.WriteError(statusError(http.StatusConflict), w, req, nil, problem.WriteOptions{LogDisabled: true}))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "https://example.com/probs/typed", body["type"])
+}
@@ -30,6 +30,10 @@
 // A Problem is an error and, as such, can wrap another error and can be wrapped. Wrap (and Builder.Wrap) can be used
 // for wrapped while As and Is can be used for unwrapping.
 //
+// Problems can be written to HTTP responses (e.g. WriteProblem and WriteError) as well as read from HTTP responses
+// received from other services using ReadResponse. A Registry can be used to link problems that are read back to the
+// Definition that describes them and decode them into concrete Go types.
+//
 // The package also provides opt-in support for stack trace capturing and UUID generation for problems along with the
 // concept of a problem Code.
 package problem
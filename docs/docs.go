@@ -0,0 +1,367 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package docs provides an http.Handler that serves human-readable documentation for problem types at the paths of
+// their type URI references, allowing the "type" member of each problem to resolve as encouraged by RFC 9457.
+//
+// A page is served for each problem.Type, listing its title, status, documentation (see problem.Documentation), and the
+// codes, details, documentation, and example bodies of any problem.Definition using it. A JSON index of all types is also served. For example;
+//
+//	h := docs.FromCatalog(c)
+//	mux.Handle("/problems/", h)
+//	// GET /problems/out-of-credit     -> HTML page for "https://example.com/problems/out-of-credit"
+//	// GET /problems/                  -> HTML index, or JSON if preferred by the Accept header
+//	// GET /problems/index.json        -> JSON index
+//
+// Since pages are matched using the path of each type URI reference, the Handler must be mounted without stripping any
+// path prefix.
+package docs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"html/template"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/jay-babu/go-problem/catalog"
+	"github.com/jay-babu/go-problem/internal/accept"
+)
+
+type (
+	// DefinitionDoc contains the documentation of a problem.Definition using a documented problem.Type.
+	DefinitionDoc struct {
+		// Code is the code of the definition, if any.
+		Code string `json:"code,omitempty"`
+		// Detail is the default detail of the definition, if any.
+		Detail string `json:"detail,omitempty"`
+		// Docs contains the documentation specific to the definition, if any, excluding that of its type.
+		Docs problem.Documentation `json:"docs,omitzero"`
+		// Example is the JSON representation of an example problem generated from the definition.
+		Example json.RawMessage `json:"example,omitempty"`
+		// Name is the name of the definition, if known.
+		Name string `json:"name,omitempty"`
+	}
+
+	// Handler is an http.Handler that serves documentation for problem types at the paths of their type URI
+	// references. See the package documentation for more information.
+	//
+	// The zero value is ready to use and a Handler is safe for concurrent use, including while types and definitions
+	// are being added. A Handler must not be copied after first use.
+	Handler struct {
+		// Generator is the problem.Generator used to build the example problem for each definition, allowing any
+		// translation keys to be resolved, for example.
+		//
+		// If nil, problem.DefaultGenerator is used.
+		Generator *problem.Generator
+		// IndexTemplate is the html/template used to render the HTML index, which is executed with an Index.
+		//
+		// If nil, a built-in template is used.
+		IndexTemplate *template.Template
+		// Template is the html/template used to render the HTML page for each problem type, which is executed with a
+		// TypeDoc.
+		//
+		// If nil, a built-in template is used.
+		Template *template.Template
+		// docs contains the documentation for each problem type mapped to the path of its type URI reference.
+		docs map[string]*TypeDoc
+		// mu is used to guard docs.
+		mu sync.RWMutex
+	}
+
+	// Index contains the documentation of all problem types served by a Handler.
+	Index struct {
+		// Types contains the documentation of each problem type, sorted by type URI reference.
+		Types []TypeDoc `json:"types"`
+	}
+
+	// TypeDoc contains the documentation of a problem.Type.
+	TypeDoc struct {
+		// Codes contains the codes of all definitions using the type.
+		Codes []string `json:"codes,omitempty"`
+		// Definitions contains the documentation of each definition using the type.
+		Definitions []DefinitionDoc `json:"definitions,omitempty"`
+		// Docs contains the documentation of the type, if any.
+		Docs problem.Documentation `json:"docs,omitzero"`
+		// Path is the path of URI, at which the documentation is served.
+		Path string `json:"path"`
+		// Status is the default status of the type.
+		Status int `json:"status,omitempty"`
+		// Title is the default title of the type.
+		Title string `json:"title,omitempty"`
+		// URI is the type URI reference.
+		URI string `json:"uri"`
+	}
+)
+
+// indexFileName is the name of the file at which the JSON index is always served, regardless of the Accept header.
+const indexFileName = "index.json"
+
+var _ http.Handler = (*Handler)(nil)
+
+// Add adds documentation for the given problem.Definition, and the problem.Type it uses, to the Handler, where name is
+// the name of the definition, if known.
+//
+// Definitions whose type has no URI reference, or uses problem.DefaultTypeURI, are ignored as they cannot be resolved.
+func (h *Handler) Add(name string, def problem.Definition) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	doc := h.typeDoc(def.Type)
+	if doc == nil {
+		return
+	}
+
+	example := h.example(def)
+	if doc.Title == "" {
+		doc.Title = example.Title
+	}
+	dd := DefinitionDoc{
+		Code:   string(def.Code),
+		Detail: example.Detail,
+		Docs:   def.Docs,
+		Name:   name,
+	}
+	if b, err := json.MarshalIndent(example, "", "  "); err == nil {
+		dd.Example = b
+	}
+	doc.Definitions = append(doc.Definitions, dd)
+	slices.SortStableFunc(doc.Definitions, func(a, b DefinitionDoc) int {
+		return strings.Compare(a.Name+"\x00"+a.Code, b.Name+"\x00"+b.Code)
+	})
+	if dd.Code != "" && !slices.Contains(doc.Codes, dd.Code) {
+		doc.Codes = append(doc.Codes, dd.Code)
+		slices.Sort(doc.Codes)
+	}
+}
+
+// AddType adds documentation for the given problem.Type to the Handler.
+//
+// Types with no URI reference, or using problem.DefaultTypeURI, are ignored as they cannot be resolved.
+func (h *Handler) AddType(t problem.Type) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.typeDoc(t)
+}
+
+// Index returns the documentation of all problem types served by the Handler.
+func (h *Handler) Index() Index {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	index := Index{Types: make([]TypeDoc, 0, len(h.docs))}
+	for _, doc := range h.docs {
+		index.Types = append(index.Types, doc.clone())
+	}
+	slices.SortFunc(index.Types, func(a, b TypeDoc) int {
+		return strings.Compare(a.URI, b.URI)
+	})
+	return index
+}
+
+// ServeHTTP serves the documentation for the problem type whose type URI reference has the same path as the request,
+// if any.
+//
+// Documentation is served as HTML unless the Accept header of the request prefers JSON. Requests for a path ending in
+// a slash that does not match any problem type are served the index, with requests for a path ending in "/index.json"
+// always being served the JSON index. Otherwise, a 404 Not Found response is served.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	p := req.URL.Path
+	h.mu.RLock()
+	doc, found := h.docs[normalizePath(p)]
+	var tdoc TypeDoc
+	if found {
+		tdoc = doc.clone()
+	}
+	h.mu.RUnlock()
+
+	switch {
+	case found:
+		h.write(w, req, false, tdoc, h.pageTemplate())
+	case strings.HasSuffix(p, "/"+indexFileName):
+		h.write(w, req, true, h.Index(), nil)
+	case strings.HasSuffix(p, "/"):
+		h.write(w, req, false, h.Index(), h.indexTemplate())
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// example returns an example problem.Problem generated from the given problem.Definition.
+func (h *Handler) example(def problem.Definition) *problem.Problem {
+	gen := h.Generator
+	if gen == nil {
+		gen = problem.DefaultGenerator
+	}
+	return def.BuildContextUsing(context.Background(), gen).
+		Stack(problem.FlagDisable).
+		UUID(problem.FlagDisable).
+		Problem()
+}
+
+// indexTemplate returns Handler.IndexTemplate if not nil, otherwise defaultIndexTemplate.
+func (h *Handler) indexTemplate() *template.Template {
+	if t := h.IndexTemplate; t != nil {
+		return t
+	}
+	return defaultIndexTemplate
+}
+
+// pageTemplate returns Handler.Template if not nil, otherwise defaultTypeTemplate.
+func (h *Handler) pageTemplate() *template.Template {
+	if t := h.Template; t != nil {
+		return t
+	}
+	return defaultTypeTemplate
+}
+
+// typeDoc returns the TypeDoc for the given problem.Type, creating it if it does not already exist, or nil if it has no
+// resolvable URI reference.
+//
+// h.mu must be locked for writing by the caller.
+func (h *Handler) typeDoc(t problem.Type) *TypeDoc {
+	if t.URI == "" || t.URI == problem.DefaultTypeURI {
+		return nil
+	}
+	u, err := url.Parse(t.URI)
+	if err != nil || u.Path == "" {
+		return nil
+	}
+
+	key := normalizePath(u.Path)
+	if doc, found := h.docs[key]; found {
+		if doc.Docs.IsZero() {
+			doc.Docs = t.Docs
+		}
+		return doc
+	}
+	if h.docs == nil {
+		h.docs = make(map[string]*TypeDoc)
+	}
+	doc := &TypeDoc{
+		Docs:   t.Docs,
+		Path:   u.Path,
+		Status: t.Status,
+		Title:  t.Title,
+		URI:    t.URI,
+	}
+	h.docs[key] = doc
+	return doc
+}
+
+// write writes the given data as either JSON or HTML, rendered using the html/template provided, based on the Accept
+// header of the request, unless forceJSON is true. HTML is written if neither is acceptable.
+func (h *Handler) write(w http.ResponseWriter, req *http.Request, forceJSON bool, data any, tmpl *template.Template) {
+	mediaType := "application/json"
+	if !forceJSON {
+		var acceptable bool
+		mediaType, acceptable = accept.NegotiateMediaType(req.Header.Get("Accept"), []string{"text/html", "application/json"})
+		if !acceptable {
+			mediaType = "text/html"
+		}
+	}
+
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	if mediaType == "application/json" {
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(data)
+	} else {
+		err = tmpl.Execute(&buf, data)
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(http.StatusOK)
+	if req.Method != http.MethodHead {
+		_, _ = buf.WriteTo(w)
+	}
+}
+
+// clone returns a deep copy of the TypeDoc.
+func (d *TypeDoc) clone() TypeDoc {
+	c := *d
+	c.Codes = slices.Clone(d.Codes)
+	c.Definitions = slices.Clone(d.Definitions)
+	return c
+}
+
+// FromCatalog returns a Handler serving documentation for all types and definitions within the given
+// catalog.Catalog.
+func FromCatalog(c *catalog.Catalog) *Handler {
+	h := &Handler{}
+	for _, name := range c.TypeNames() {
+		t, _ := c.Type(name)
+		h.AddType(t)
+	}
+	for _, name := range c.DefinitionNames() {
+		def, _ := c.Definition(name)
+		h.Add(name, def)
+	}
+	return h
+}
+
+// FromDefinitions returns a Handler serving documentation for the given definitions, mapped to their names, and the
+// types they use.
+func FromDefinitions(defs map[string]problem.Definition) *Handler {
+	h := &Handler{}
+	for _, name := range slices.Sorted(maps.Keys(defs)) {
+		h.Add(name, defs[name])
+	}
+	return h
+}
+
+// FromRegistry returns a Handler serving documentation for all definitions within the given problem.Registry and the
+// types they use.
+func FromRegistry(r *problem.Registry) *Handler {
+	h := &Handler{}
+	for _, def := range r.Definitions() {
+		h.Add("", def)
+	}
+	return h
+}
+
+// normalizePath returns the given path without any trailing slash, unless it is the root path.
+func normalizePath(p string) string {
+	if len(p) > 1 {
+		return strings.TrimSuffix(p, "/")
+	}
+	return p
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package docs_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/jay-babu/go-problem/docs"
+	"github.com/jay-babu/go-problem/uri"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	outOfCredit := problem.Type{
+		Docs: problem.Documentation{
+			Description: "The account cannot cover the cost of the transaction.",
+			Links:       []problem.Link{{Title: "Pricing", URL: "https://example.com/pricing"}},
+			Owner:       "billing",
+			Tags:        []string{"billing", "payments"},
+		},
+		Status: http.StatusForbidden,
+		Title:  "You do not have enough credit.",
+		URI:    uri.Build().Base("https://example.com").Path("/problems/:name").PathValue("name", "out-of-credit").String(),
+	}
+	h := docs.FromDefinitions(map[string]problem.Definition{
+		"OutOfCredit": {
+			Code:   "BILL-403",
+			Detail: "Top up <now>",
+			Docs:   problem.Documentation{Resolution: []string{"Top up the account.", "Retry the transaction."}},
+			Type:   outOfCredit,
+		},
+		"Unresolvable":   {Code: "X-500"},
+		"OutOfCredit2":   {Code: "BILL-404", Type: outOfCredit},
+		"DefaultTypeURI": {Type: problem.Type{URI: problem.DefaultTypeURI}},
+	})
+
+	serve := func(path, accept string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/problems/out-of-credit", "text/html")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "<h1>You do not have enough credit.</h1>")
+	assert.Contains(t, rec.Body.String(), "BILL-403, BILL-404")
+	assert.Contains(t, rec.Body.String(), "Top up &lt;now&gt;")
+	assert.Contains(t, rec.Body.String(), "<p>The account cannot cover the cost of the transaction.</p>")
+	assert.Contains(t, rec.Body.String(), `<li><a href="https://example.com/pricing">Pricing</a></li>`)
+	assert.Contains(t, rec.Body.String(), "<dt>Owner</dt><dd>billing</dd>")
+	assert.Contains(t, rec.Body.String(), "<li>Top up the account.</li>\n<li>Retry the transaction.</li>")
+
+	rec = serve("/problems/out-of-credit/", "application/json")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var doc docs.TypeDoc
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "https://example.com/problems/out-of-credit", doc.URI)
+	assert.Equal(t, []string{"BILL-403", "BILL-404"}, doc.Codes)
+	require.Len(t, doc.Definitions, 2)
+	assert.Equal(t, "OutOfCredit", doc.Definitions[0].Name)
+	assert.Equal(t, "billing", doc.Docs.Owner)
+	assert.Equal(t, []string{"Top up the account.", "Retry the transaction."}, doc.Definitions[0].Docs.Resolution)
+	assert.JSONEq(t, `{
+		"code": "BILL-403",
+		"detail": "Top up <now>",
+		"status": 403,
+		"title": "You do not have enough credit.",
+		"type": "https://example.com/problems/out-of-credit"
+	}`, string(doc.Definitions[0].Example))
+
+	rec = serve("/problems/index.json", "text/html")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var index docs.Index
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &index))
+	require.Len(t, index.Types, 1)
+	assert.Equal(t, "/problems/out-of-credit", index.Types[0].Path)
+
+	rec = serve("/problems/", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `<a href="/problems/out-of-credit">You do not have enough credit.</a>`)
+	assert.Contains(t, rec.Body.String(), "<td>billing, payments</td>")
+
+	for _, accept := range []string{"text/plain", "application/json;q=0, text/html;q=0"} {
+		rec = serve("/problems/out-of-credit", accept)
+		require.Equal(t, http.StatusOK, rec.Code, accept)
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"), accept)
+		assert.Contains(t, rec.Body.String(), "<h1>You do not have enough credit.</h1>", accept)
+	}
+
+	assert.Equal(t, http.StatusNotFound, serve("/problems/unknown", "").Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/problems/out-of-credit", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
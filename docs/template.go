@@ -0,0 +1,99 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package docs
+
+import "html/template"
+
+// style contains the CSS shared by the built-in templates, consistent with the HTML rendering of problems.
+const style = `<style>
+body{font-family:system-ui,-apple-system,"Segoe UI",Roboto,sans-serif;color:#1f2328;margin:0;padding:3rem 1.5rem;background:#f6f8fa}
+main{max-width:48rem;margin:0 auto;background:#fff;border:1px solid #d0d7de;border-radius:.5rem;padding:2rem}
+h1{margin:0 0 .5rem;font-size:1.75rem}
+h2{margin:2rem 0 .5rem;font-size:1.25rem}
+.status{color:#cf222e;font-weight:600;margin:0}
+dl{display:grid;grid-template-columns:max-content 1fr;gap:.5rem 1rem;margin:1.5rem 0 0}
+dt{font-weight:600}
+dd{margin:0;overflow-wrap:anywhere}
+pre{background:#f6f8fa;padding:1rem;border-radius:.375rem;overflow:auto}
+table{border-collapse:collapse;width:100%}
+th,td{text-align:left;padding:.5rem;border-bottom:1px solid #d0d7de}
+</style>`
+
+var (
+	// defaultIndexTemplate is the html/template used to render an Index when Handler.IndexTemplate is nil.
+	defaultIndexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Problem Types</title>
+` + style + `
+</head>
+<body>
+<main>
+<h1>Problem Types</h1>
+<table>
+<thead><tr><th>Status</th><th>Title</th><th>Codes</th><th>Tags</th></tr></thead>
+<tbody>
+{{range .Types}}<tr><td>{{.Status}}</td><td><a href="{{.Path}}">{{.Title}}</a></td><td>{{range $i, $code := .Codes}}{{if $i}}, {{end}}{{$code}}{{end}}</td><td>{{range $i, $tag := .Docs.Tags}}{{if $i}}, {{end}}{{$tag}}{{end}}</td></tr>
+{{end}}</tbody>
+</table>
+</main>
+</body>
+</html>
+`))
+
+	// defaultTypeTemplate is the html/template used to render a TypeDoc when Handler.Template is nil.
+	defaultTypeTemplate = template.Must(template.New("type").Parse(`{{define "docs"}}{{with .Description}}<p>{{.}}</p>
+{{end}}{{with .Resolution}}<h3>Resolution</h3>
+<ol>
+{{range .}}<li>{{.}}</li>
+{{end}}</ol>
+{{end}}{{with .Links}}<ul>
+{{range .}}<li><a href="{{.URL}}">{{with .Title}}{{.}}{{else}}{{.URL}}{{end}}</a></li>
+{{end}}</ul>
+{{end}}{{end}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+` + style + `
+</head>
+<body>
+<main>
+{{with .Status}}<p class="status">{{.}}</p>
+{{end}}<h1>{{.Title}}</h1>
+{{template "docs" .Docs}}<dl>
+<dt>Type</dt><dd>{{.URI}}</dd>
+{{with .Codes}}<dt>Codes</dt><dd>{{range $i, $code := .}}{{if $i}}, {{end}}{{$code}}{{end}}</dd>
+{{end}}{{with .Docs.Owner}}<dt>Owner</dt><dd>{{.}}</dd>
+{{end}}{{with .Docs.Tags}}<dt>Tags</dt><dd>{{range $i, $tag := .}}{{if $i}}, {{end}}{{$tag}}{{end}}</dd>
+{{end}}</dl>
+{{range .Definitions}}<h2>{{with .Name}}{{.}}{{else}}{{.Code}}{{end}}</h2>
+{{with .Detail}}<p>{{.}}</p>
+{{end}}{{template "docs" .Docs}}{{with .Docs.Owner}}<p>Owner: {{.}}</p>
+{{end}}{{with .Example}}<pre>{{printf "%s" .}}</pre>
+{{end}}{{end}}</main>
+</body>
+</html>
+`))
+)
@@ -0,0 +1,80 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import "slices"
+
+type (
+	// Documentation contains optional human-readable documentation for a Type or Definition, intended to help
+	// consumers and support engineers understand what a problem means and how it can be resolved.
+	//
+	// Documentation is never included when a Problem is serialized, however, it can be carried through to generated
+	// documentation, API specifications, and catalog files.
+	Documentation struct {
+		// Description is a human-readable description of the problem, typically explaining when and why it occurs.
+		Description string `json:"description,omitempty" xml:"description,omitempty" yaml:"description,omitempty"`
+		// Links contains any links to related resources (e.g. runbooks or specifications).
+		Links []Link `json:"links,omitempty" xml:"link,omitempty" yaml:"links,omitempty"`
+		// Owner is the team or individual responsible for the problem.
+		Owner string `json:"owner,omitempty" xml:"owner,omitempty" yaml:"owner,omitempty"`
+		// Resolution contains ordered steps that can be taken to resolve the problem.
+		Resolution []string `json:"resolution,omitempty" xml:"resolution,omitempty" yaml:"resolution,omitempty"`
+		// Tags contains any tags used to categorize the problem.
+		Tags []string `json:"tags,omitempty" xml:"tag,omitempty" yaml:"tags,omitempty"`
+	}
+
+	// Link represents a link to a resource related to a problem.
+	Link struct {
+		// Title is the human-readable title of the link.
+		Title string `json:"title,omitempty" xml:"title,omitempty" yaml:"title,omitempty"`
+		// URL is the URL of the linked resource.
+		URL string `json:"url" xml:"url" yaml:"url"`
+	}
+)
+
+// IsZero returns whether the Documentation contains no documentation.
+func (d Documentation) IsZero() bool {
+	return d.Description == "" && len(d.Links) == 0 && d.Owner == "" && len(d.Resolution) == 0 && len(d.Tags) == 0
+}
+
+// Merge returns a Documentation where the fields of the given Documentation are used as fallbacks for any empty fields
+// within the Documentation, except for Links and Tags, which are combined (Tags being deduplicated).
+//
+// This is typically used to combine the Documentation of a Definition with that of its Type. See
+// Definition.Documentation for more information.
+func (d Documentation) Merge(fallback Documentation) Documentation {
+	m := Documentation{
+		Description: firstNonZeroValue(d.Description, fallback.Description),
+		Links:       slices.Concat(d.Links, fallback.Links),
+		Owner:       firstNonZeroValue(d.Owner, fallback.Owner),
+		Resolution:  slices.Clone(d.Resolution),
+		Tags:        slices.Clone(d.Tags),
+	}
+	if len(m.Resolution) == 0 {
+		m.Resolution = slices.Clone(fallback.Resolution)
+	}
+	for _, tag := range fallback.Tags {
+		if !slices.Contains(m.Tags, tag) {
+			m.Tags = append(m.Tags, tag)
+		}
+	}
+	return m
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrExtensionNotFound is returned by ExtensionAs when a Problem does not contain an extension with a given key.
+var ErrExtensionNotFound = errors.New("problem extension not found")
+
+// ExtensionAs returns the value of the extension with the given key within the Problem as a T.
+//
+// If the value is already a T, it is returned as-is. Otherwise, the value is converted by marshaling it into JSON and
+// unmarshaling it into a T. This is particularly useful when the Problem has itself been unmarshaled (e.g. by
+// ReadResponse), in which case JSON numbers will be float64 and JSON objects will be map[string]any. For example;
+//
+//	type RateLimit struct {
+//		Limit     int       `json:"limit"`
+//		Remaining int       `json:"remaining"`
+//		Reset     time.Time `json:"reset"`
+//	}
+//
+//	limit, err := problem.ExtensionAs[RateLimit](prob, "rateLimit")
+//
+// An error is returned if p is nil or does not contain an extension with key, in which case it will wrap
+// ErrExtensionNotFound, or if the value cannot be converted into a T.
+func ExtensionAs[T any](p *Problem, key string) (T, error) {
+	var t T
+	value, found := p.Extension(key)
+	if !found {
+		return t, fmt.Errorf("%w: %q", ErrExtensionNotFound, key)
+	}
+	t, err := convertValue[T](value)
+	if err != nil {
+		return t, fmt.Errorf("problem extension %q: %w", key, err)
+	}
+	return t, nil
+}
+
+// ValidateExtensionKey returns an error if the given key cannot be used as the key of an extension, as it is either
+// empty or reserved (i.e. it is the name of a member of a problem document, such as "status" or "title").
+func ValidateExtensionKey(key string) error {
+	return validationExtensionKey(key)
+}
+
+// convertValue returns the given value as a T, converting it via its JSON representation if it is not already a T.
+func convertValue[T any](value any) (T, error) {
+	var t T
+	if v, ok := value.(T); ok {
+		return v, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return t, err
+	}
+	err = json.Unmarshal(b, &t)
+	return t, err
+}
+
+// extensionsFrom returns Extensions containing an entry for each field within the given struct (or pointer to a
+// struct) or each entry within the given map with string keys.
+//
+// Struct fields are named and omitted based on their "json" tags in the same way as encoding/json, including fields
+// promoted from embedded structs, with the original field values being retained. Nil values result in nil Extensions.
+//
+// An error is returned if v is of any other type or contains a key that is either empty or reserved (i.e. conflicts with
+// Problem-level fields).
+func extensionsFrom(v any) (Extensions, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+
+	var es Extensions
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported extensions map key type: %v", rv.Type().Key())
+		}
+		es = make(Extensions, rv.Len())
+		for it := rv.MapRange(); it.Next(); {
+			es[it.Key().String()] = it.Value().Interface()
+		}
+	case reflect.Struct:
+		es = make(Extensions, rv.NumField())
+		putStructExtensions(es, make(map[string]int), rv, 0)
+	default:
+		return nil, fmt.Errorf("unsupported extensions type: %v", rv.Type())
+	}
+	for k := range es {
+		if err := validationExtensionKey(k); err != nil {
+			return nil, err
+		}
+	}
+	return es, nil
+}
+
+// putStructExtensions puts an entry into es for each applicable field within the given struct value, recursing into
+// embedded structs without a name so that their fields are promoted.
+//
+// As with encoding/json, a field at a shallower depth takes precedence over one with the same name at a deeper depth,
+// which are tracked within depths.
+func putStructExtensions(es Extensions, depths map[string]int, rv reflect.Value, depth int) {
+	var nested []reflect.Value
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := rv.Field(i)
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if fv.Kind() == reflect.Pointer {
+					if fv.IsNil() {
+						continue
+					}
+					fv = fv.Elem()
+				}
+				nested = append(nested, fv)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if hasTagOption(opts, "omitempty") && isEmptyValue(fv) {
+			continue
+		}
+		if hasTagOption(opts, "omitzero") && fv.IsZero() {
+			continue
+		}
+		if d, found := depths[name]; found && d <= depth {
+			continue
+		}
+		depths[name] = depth
+		es[name] = fv.Interface()
+	}
+
+	for _, fv := range nested {
+		putStructExtensions(es, depths, fv, depth+1)
+	}
+}
+
+// hasTagOption returns whether the given comma-separated struct tag options contains the option provided.
+func hasTagOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue returns whether the given value is considered empty in the same way as the "omitempty" option for
+// encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	default:
+		return false
+	}
+}
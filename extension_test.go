@@ -0,0 +1,120 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+type testConflict struct {
+	RateLimit `json:"rateLimit"`
+	Resource
+	ResourceIDs []string `json:"resourceIds,omitempty"`
+	Ignored     string   `json:"-"`
+	internal    string
+}
+
+type Resource struct {
+	Kind        string   `json:"kind"`
+	ResourceIDs []string `json:"resourceIds"`
+}
+
+func TestBuilder_ExtensionsFrom(t *testing.T) {
+	reset := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	prob := problem.Build().
+		ExtensionsFrom(&testConflict{
+			RateLimit: RateLimit{Limit: 100, Reset: reset},
+			Resource:  Resource{Kind: "account", ResourceIDs: []string{"a"}},
+			Ignored:   "ignored",
+			internal:  "internal",
+		}).
+		Problem()
+
+	assert.Equal(t, problem.Extensions{
+		"kind":        "account",
+		"rateLimit":   RateLimit{Limit: 100, Reset: reset},
+		"resourceIds": []string{"a"},
+	}, prob.Extensions)
+
+	assert.Panics(t, func() {
+		problem.Build().ExtensionsFrom(struct {
+			Status int `json:"status"`
+		}{})
+	})
+	assert.Panics(t, func() {
+		problem.Build().ExtensionsFrom(42)
+	})
+}
+
+func TestExtensionAs(t *testing.T) {
+	reset := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	data, err := json.Marshal(problem.Build().
+		Extension("rateLimit", RateLimit{Limit: 100, Remaining: 5, Reset: reset}).
+		Extension("retries", 3).
+		Problem())
+	require.NoError(t, err)
+
+	var prob problem.Problem
+	require.NoError(t, json.Unmarshal(data, &prob))
+
+	limit, err := problem.ExtensionAs[RateLimit](&prob, "rateLimit")
+	require.NoError(t, err)
+	assert.Equal(t, RateLimit{Limit: 100, Remaining: 5, Reset: reset}, limit)
+
+	retries, err := problem.ExtensionAs[int](&prob, "retries")
+	require.NoError(t, err)
+	assert.Equal(t, 3, retries)
+
+	_, err = problem.ExtensionAs[int](&prob, "missing")
+	assert.ErrorIs(t, err, problem.ErrExtensionNotFound)
+
+	_, err = problem.ExtensionAs[int](&prob, "rateLimit")
+	assert.Error(t, err)
+
+	var dst struct {
+		RateLimit RateLimit `json:"rateLimit"`
+		Retries   int       `json:"retries"`
+	}
+	require.NoError(t, prob.DecodeExtensions(&dst))
+	assert.Equal(t, limit, dst.RateLimit)
+	assert.Equal(t, 3, dst.Retries)
+}
+
+func TestValidateExtensionKey(t *testing.T) {
+	assert.NoError(t, problem.ValidateExtensionKey("balance"))
+	assert.EqualError(t, problem.ValidateExtensionKey(""), "extension key cannot be empty")
+	for _, key := range []string{"code", "detail", "extensions", "instance", "stack", "status", "title", "type", "uuid"} {
+		assert.EqualError(t, problem.ValidateExtensionKey(key), fmt.Sprintf("extension key is reserved: %q", key))
+	}
+}
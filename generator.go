@@ -20,8 +20,21 @@
 
 package problem
 
+import "html/template"
+
 // Generator is responsible for generating a Problem. Its zero value (DefaultGenerator) is usable.
 type Generator struct {
+	// CauseSelector is the CauseSelector used by Builder.Wrap, Builder.WrapAll, and their corresponding options to
+	// select which of multiple causes is passed to the Unwrapper (e.g. when wrapping the result of errors.Join).
+	//
+	// If nil, FirstProblemCauseSelector will be used.
+	//
+	// For example;
+	//
+	//	g := &Generator{CauseSelector: func(causes []error) error {
+	//		return causes[len(causes)-1]
+	//	}}
+	CauseSelector CauseSelector
 	// CodeNamespaceValidator is the CodeNamespaceValidator used to perform additional validation on a CodeNamespace
 	// used within a Code constructed and/or parsed by Generator.
 	//
@@ -66,8 +79,47 @@ type Generator struct {
 	// Generator.WriteProblem are called without a WriteOptions.ContentType being passed. This also applies to the
 	// Middleware functions as they call Generator.WriteError internally.
 	//
+	// When Negotiation is enabled, ContentType is the default content/media type used when the Accept header of the HTTP
+	// request has no preference between it and any other supported content/media type.
+	//
 	// If empty, ContentTypeJSONUTF8 will be used.
 	ContentType string
+	// HTMLTemplate is the html/template used to render a Problem when it is written to an HTTP response in HTML format
+	// (e.g. via Generator.WriteProblemHTML or when ContentTypeHTML is negotiated). The template is executed with the
+	// *Problem as its data.
+	//
+	// If nil, a built-in template will be used that renders all non-empty fields, including extensions, all of which
+	// are escaped accordingly.
+	//
+	// For example;
+	//
+	//	tmpl := template.Must(template.New("problem").Parse(`<h1>{{.Title}}</h1><p>{{.Detail}}</p>`))
+	//	g := &Generator{HTMLTemplate: tmpl}
+	HTMLTemplate *template.Template
+	// Languages contains the language tags (e.g. "en" or "fr-CA") supported by Generator.Translator, in order of
+	// preference, where the first is the default language.
+	//
+	// When a Problem is written to an HTTP response (e.g. via Generator.WriteProblem or the Middleware functions), its
+	// title and detail are localized again in the language negotiated using the Accept-Language header of the HTTP
+	// request, based on the translation keys from which they were resolved when the Problem was constructed (see
+	// Generator.Localize for more information), and the Content-Language header is populated with the negotiated language,
+	// but only if any value was actually localized. The negotiated language is passed to Generator.Translator within the
+	// context.Context (see GetLanguage for more information). If none of the languages are acceptable, the default
+	// language is used. Any language already within the context.Context of the HTTP request (see UsingLanguage) takes
+	// precedence over negotiation.
+	//
+	// Regardless of the language of the HTTP response, any Problem that is logged when written is localized in the
+	// default language so that logs remain consistent.
+	//
+	// If empty, no language is negotiated and problems are only localized again when written if the context.Context of
+	// the HTTP request contains a language.
+	//
+	// For example;
+	//
+	//	g := &Generator{Languages: []string{"en", "fr", "de"}, Translator: translator}
+	//	// Accept-Language: fr-CA, en;q=0.8 -> Content-Language: fr
+	//	// Accept-Language: es              -> Content-Language: en
+	Languages []string
 	// LogArgKey is the key passed along with a Problem within the last two arguments to Generator.Logger.
 	//
 	// If empty, DefaultLogArgKey will be passed.
@@ -103,6 +155,62 @@ type Generator struct {
 	//	logger := slog.NewLogLogger(slog.NewJSONHandler(os.Stderr, nil), slog.LevelDebug)
 	//	g := &Generator{Logger: LoggerFrom(logger)}
 	Logger Logger
+	// Mapper is the problem.Mapper used to map errors that are not problems to a Definition, along with any options
+	// derived from the error. See Mapper for more information.
+	//
+	// Mapper is used by Builder.Wrap and Wrap when the wrapped error does not contain a Problem in its tree and no
+	// Definition has been explicitly provided, as well as by Generator.WriteError and the Middleware functions before
+	// falling back to the function provided to them.
+	//
+	// If nil, errors are never mapped.
+	//
+	// For example;
+	//
+	//	g := &Generator{Mapper: NewMapper(MapIs(sql.ErrNoRows, NotFoundDefinition))}
+	//	prob := g.New(Wrap(fmt.Errorf("find user: %w", sql.ErrNoRows)))
+	//	// prob.Status == http.StatusNotFound
+	Mapper *Mapper
+	// Negotiation provides control over whether the content/media type used by Generator.WriteError,
+	// Generator.WriteProblem, and the Middleware functions is negotiated using the Accept header of the HTTP request
+	// when no WriteOptions.ContentType is passed.
+	//
+	// When negotiating, the supported content/media types are ContentTypeJSON, ContentTypeXML, ContentTypeGenericJSON,
+	// ContentTypeGenericXML, and ContentTypeHTML, with ContentType being preferred when the Accept header has no
+	// preference. This allows browser clients to be served a human-readable page (see HTMLTemplate). If none are
+	// acceptable, NegotiationLenient falls back to ContentType while NegotiationStrict responds with
+	// http.StatusNotAcceptable.
+	//
+	// If zero, NegotiationDisabled will be used.
+	//
+	// For example;
+	//
+	//	g := &Generator{Negotiation: NegotiationLenient}
+	//	// Accept: application/xml                    -> Content-Type: application/xml; charset=utf-8
+	//	// Accept: application/problem+xml, */*;q=0.1 -> Content-Type: application/problem+xml; charset=utf-8
+	//	// Accept: text/plain                         -> Content-Type: application/problem+json; charset=utf-8
+	Negotiation Negotiation
+	// Redactor is the problem.Redactor used to redact a Problem before it is written to an HTTP response (e.g. via
+	// Generator.WriteProblem or the Middleware functions) or when Generator.Redact is called by any other serializer.
+	//
+	// Redaction is always applied to a clone of the Problem after it has been logged so that the logs still contain
+	// the full data.
+	//
+	// If nil, problems are never redacted.
+	//
+	// For example;
+	//
+	//	g := &Generator{Redactor: ProductionRedactor("query")}
+	//	// Stack traces and "query" extensions are removed from all problems written to HTTP responses, and the detail of
+	//	// any problem with a 5xx status is replaced with a generic detail
+	Redactor Redactor
+	// Registry contains any Definition and/or Go types registered against problem type URI references, allowing
+	// problems decoded by Generator.ReadResponse, Generator.DecodeJSON, and Generator.DecodeXML to be linked to their
+	// Definition and decoded into a concrete typed value. See Registry for more information.
+	//
+	// Problem.UnmarshalJSON and Problem.UnmarshalXML can only ever use the Registry of DefaultGenerator.
+	//
+	// If nil, decoded problems are not linked to any Definition or Go type.
+	Registry *Registry
 	// StackFlag provides control over the capturing of a stack trace and its visibility on a Problem.
 	//
 	// StackFlag is the default Flag. If Builder.Stack or WithStack are used, but no flags are provided, this is
@@ -118,7 +226,8 @@ type Generator struct {
 	//	g := &Generator{StackFlag: FlagField | FlagLog}  // Stack trace accessible via Problem.Stack and visible in logs
 	StackFlag Flag
 	// Translator is the problem.Translator used to provide localized values for translation keys, where possible, when
-	// constructing a Problem.
+	// constructing a Problem and when localizing it again (e.g. when writing it to an HTTP response, see
+	// Generator.Languages for more information).
 	//
 	// If nil, NoopTranslator will be used, which will always return an empty string, forcing the Problem to be
 	// constructed using a fallback value for the associated field.
@@ -211,8 +320,13 @@ type Generator struct {
 //     more information)
 //   - Any stack trace, UUID, or LogLevel of a Problem found in the tree of an error passed to Builder.Wrap or Wrap is
 //     unwrapped and treated as defaults for the generated Problem by default (see Generator.Unwrapper for more
-//     information)
+//     information), and, when multiple errors are wrapped, the first whose tree contains a Problem is unwrapped (see
+//     Generator.CauseSelector for more information)
+//   - Errors passed to Builder.Wrap or Wrap that are not problems are never mapped to a Definition (see
+//     Generator.Mapper for more information)
 //   - Any translation keys are ignored (see Generator.Translator for more information)
+//   - Problems are written to HTTP responses without negotiating their language using the Accept-Language header of
+//     the HTTP request (see Generator.Languages for more information)
 //   - Any Code constructed and/or parsed can have any non-empty CodeNamespace and value and are separated by
 //     DefaultCodeSeparator (see Generator.CodeNamespaceValidator, Generator.CodeValueLen, and Generator.CodeSeparator
 //     respectively for more information)
@@ -220,4 +334,7 @@ type Generator struct {
 //     DefaultLogArgKey passed as the key along with a Problem within the last two arguments (see Generator.Logger and
 //     Generator.LogArgKey respectively for more information)
 //   - The LogLevel derived from a Type is always Type.LogLevel (see Generator.LogLeveler for more information)
+//   - Problems are written to HTTP responses without negotiating the content/media type using the Accept header of the
+//     HTTP request (see Generator.Negotiation for more information)
+//   - Problems are written to HTTP responses without being redacted (see Generator.Redactor for more information)
 var DefaultGenerator = &Generator{}
@@ -5,12 +5,11 @@ go 1.24
 require (
 	github.com/google/uuid v1.6.0
 	github.com/neocotic/go-optional v0.1.2
-	github.com/neocotic/go-problem v0.4.0
 	github.com/stretchr/testify v1.11.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,137 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+)
+
+// defaultHTMLTemplate is the html/template used to render a Problem in HTML format when Generator.HTMLTemplate is nil.
+//
+// All fields are escaped by html/template based on their context, including any extensions and URI references.
+var defaultHTMLTemplate = template.Must(template.New("problem").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Status}} {{.Title}}</title>
+<style>
+body{font-family:system-ui,-apple-system,"Segoe UI",Roboto,sans-serif;color:#1f2328;margin:0;padding:3rem 1.5rem;background:#f6f8fa}
+main{max-width:48rem;margin:0 auto;background:#fff;border:1px solid #d0d7de;border-radius:.5rem;padding:2rem}
+h1{margin:0 0 .5rem;font-size:1.75rem}
+.status{color:#cf222e;font-weight:600;margin:0}
+dl{display:grid;grid-template-columns:max-content 1fr;gap:.5rem 1rem;margin:1.5rem 0 0}
+dt{font-weight:600}
+dd{margin:0;overflow-wrap:anywhere}
+pre{background:#f6f8fa;padding:1rem;border-radius:.375rem;overflow:auto}
+</style>
+</head>
+<body>
+<main>
+<p class="status">{{.Status}}</p>
+<h1>{{.Title}}</h1>
+{{with .Detail}}<p>{{.}}</p>
+{{end}}<dl>
+{{if and .Type (ne .Type "about:blank")}}<dt>Type</dt><dd><a href="{{.Type}}">{{.Type}}</a></dd>
+{{end}}{{with .Instance}}<dt>Instance</dt><dd>{{.}}</dd>
+{{end}}{{with .Code}}<dt>Code</dt><dd>{{.}}</dd>
+{{end}}{{with .UUID}}<dt>UUID</dt><dd>{{.}}</dd>
+{{end}}{{range $key, $value := .Extensions}}<dt>{{$key}}</dt><dd>{{printf "%v" $value}}</dd>
+{{end}}</dl>
+{{with .Stack}}<pre>{{.}}</pre>
+{{end}}</main>
+</body>
+</html>
+`))
+
+// htmlTemplate returns Generator.HTMLTemplate if not nil, otherwise defaultHTMLTemplate.
+func (g *Generator) htmlTemplate() *template.Template {
+	if t := g.HTMLTemplate; t != nil {
+		return t
+	}
+	return defaultHTMLTemplate
+}
+
+// WriteErrorHTML writes an HTTP response for a Problem in HTML format where the Problem is unwrapped from err, where
+// possible, with the given function being used to provide a default Problem. WriteOptions can also be passed for more
+// granular control.
+//
+// If err is matched by Generator.Mapper, the mapped Problem is used instead of calling the function, in which case the
+// function may be nil. Otherwise, if the function is nil, a Problem wrapping err is used.
+//
+// An error is returned if the Problem fails to be written to w.
+func (g *Generator) WriteErrorHTML(err error, w http.ResponseWriter, req *http.Request, probFunc func(err error) *Problem, opts ...WriteOptions) error {
+	prob, isProblem := g.as(err)
+	if !isProblem {
+		prob = g.errorProblem(req.Context(), err, probFunc)
+	}
+	return g.WriteProblemHTML(prob, w, req, opts...)
+}
+
+// WriteProblemHTML writes an HTTP response for the given Problem in HTML format, rendered using Generator.HTMLTemplate,
+// optionally using WriteOptions for more granular control.
+//
+// An error is returned if prob fails to be rendered or written to w. Nothing is written to w if prob fails to be
+// rendered.
+func (g *Generator) WriteProblemHTML(prob *Problem, w http.ResponseWriter, req *http.Request, opts ...WriteOptions) error {
+	return g.writeProblemHTML(prob, w, req, WriteOptions{ContentType: ContentTypeHTMLUTF8}.apply(opts, isValidContentTypeForHTML))
+}
+
+// writeProblemHTML writes an HTTP response for the given Problem in HTML format using WriteOptions, that are expected
+// to have been applied, to determine how the response is formed and whether the Problem is logged.
+//
+// The Problem is localized in the language negotiated for the response, if any (see Generator.Languages), and redacted
+// using Generator.Redactor, if any, after it has been logged and is rendered before anything is written to w so that a
+// partial response is never written.
+//
+// An error is returned if prob fails to be rendered or written to w.
+func (g *Generator) writeProblemHTML(prob *Problem, w http.ResponseWriter, req *http.Request, opts WriteOptions) error {
+	ctx := g.languageContext(w, req)
+	if !opts.LogDisabled && opts.LogMessage != "" {
+		g.LogContext(req.Context(), opts.LogMessage, g.localizeForLog(req.Context(), prob), opts.LogArgs...)
+	}
+	prob = g.redactResponse(ctx, g.localizeResponse(ctx, w, prob), responseStatus(prob, opts))
+
+	var buf bytes.Buffer
+	if err := g.htmlTemplate().Execute(&buf, prob); err != nil {
+		return err
+	}
+
+	writeHeader(w, prob, opts)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// WriteErrorHTML is a convenient shorthand for calling Generator.WriteErrorHTML on the Generator within the given HTTP
+// request's context.Context, if any, otherwise DefaultGenerator.
+func WriteErrorHTML(err error, w http.ResponseWriter, req *http.Request, fn func(err error) *Problem, opts ...WriteOptions) error {
+	return GetGenerator(req.Context()).WriteErrorHTML(err, w, req, fn, opts...)
+}
+
+// WriteProblemHTML is a convenient shorthand for calling Generator.WriteProblemHTML on the Generator within the given
+// HTTP request's context.Context, if any, otherwise DefaultGenerator.
+func WriteProblemHTML(prob *Problem, w http.ResponseWriter, req *http.Request, opts ...WriteOptions) error {
+	return GetGenerator(req.Context()).WriteProblemHTML(prob, w, req, opts...)
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteProblemHTML(t *testing.T) {
+	prob := problem.New(
+		problem.WithStatus(http.StatusNotFound),
+		problem.WithTitle("Not Found"),
+		problem.WithDetail("No user exists with the ID provided"),
+		problem.WithType("https://example.com/probs/not-found"),
+		problem.WithInstance("/users/123"),
+		problem.WithExtension("userId", 123),
+	)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+	require.NoError(t, problem.WriteProblemHTML(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, problem.ContentTypeHTMLUTF8, rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+	assert.Contains(t, body, "<title>404 Not Found</title>")
+	assert.Contains(t, body, "<h1>Not Found</h1>")
+	assert.Contains(t, body, "<p>No user exists with the ID provided</p>")
+	assert.Contains(t, body, `<a href="https://example.com/probs/not-found">https://example.com/probs/not-found</a>`)
+	assert.Contains(t, body, "<dt>Instance</dt><dd>/users/123</dd>")
+	assert.Contains(t, body, "<dt>userId</dt><dd>123</dd>")
+}
+
+func TestWriteProblemHTML_Escaping(t *testing.T) {
+	prob := problem.New(
+		problem.WithStatus(http.StatusBadRequest),
+		problem.WithTitle("<script>alert('title')</script>"),
+		problem.WithDetail(`Field "name" must not contain <b> & </b>`),
+		problem.WithType("javascript:alert('type')"),
+		problem.WithExtension("<i>key</i>", "<img src=x onerror=alert('value')>"),
+	)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, problem.WriteProblemHTML(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+
+	body := rec.Body.String()
+	assert.NotContains(t, body, "<script>")
+	assert.NotContains(t, body, "<b>")
+	assert.NotContains(t, body, "<i>")
+	assert.NotContains(t, body, "<img")
+	assert.NotContains(t, body, `href="javascript:`)
+	assert.Contains(t, body, "<h1>&lt;script&gt;alert(&#39;title&#39;)&lt;/script&gt;</h1>")
+	assert.Contains(t, body, "<p>Field &#34;name&#34; must not contain &lt;b&gt; &amp; &lt;/b&gt;</p>")
+	assert.Contains(t, body, "<dt>&lt;i&gt;key&lt;/i&gt;</dt><dd>&lt;img src=x onerror=alert(&#39;value&#39;)&gt;</dd>")
+}
+
+func TestWriteProblemHTML_Template(t *testing.T) {
+	gen := &problem.Generator{HTMLTemplate: template.Must(template.New("custom").Parse(`<p>{{.Title}}</p>`))}
+	prob := gen.New(problem.WithStatus(http.StatusConflict), problem.WithTitle("Conflict"))
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, gen.WriteProblemHTML(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "<p>Conflict</p>", rec.Body.String())
+
+	gen.HTMLTemplate = template.Must(template.New("broken").Parse(`{{template "missing"}}`))
+	rec = httptest.NewRecorder()
+	assert.Error(t, gen.WriteProblemHTML(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+	assert.Empty(t, rec.Header().Get("Content-Type"))
+	assert.Zero(t, rec.Body.Len())
+}
+
+func TestWriteErrorHTML(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, problem.WriteErrorHTML(errors.New("boom"), rec, req, func(err error) *problem.Problem {
+		return problem.New(problem.WithStatus(http.StatusBadGateway), problem.WithTitle("Bad Gateway"))
+	}, problem.WriteOptions{LogDisabled: true}))
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+	assert.Contains(t, rec.Body.String(), "<h1>Bad Gateway</h1>")
+}
+
+func TestWriteProblem_NegotiationHTML(t *testing.T) {
+	gen := &problem.Generator{Negotiation: problem.NegotiationLenient}
+	prob := gen.New(problem.WithStatus(http.StatusForbidden), problem.WithTitle("Forbidden"))
+
+	for _, accept := range []string{
+		"text/html",
+		"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+	} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", accept)
+		require.NoError(t, gen.WriteProblem(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+		assert.Equal(t, http.StatusForbidden, rec.Code, accept)
+		assert.Equal(t, problem.ContentTypeHTMLUTF8, rec.Header().Get("Content-Type"), accept)
+		assert.Contains(t, rec.Body.String(), "<h1>Forbidden</h1>", accept)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/json, text/html;q=0.5")
+	require.NoError(t, gen.WriteProblem(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+	assert.Equal(t, problem.ContentTypeGenericJSONUTF8, rec.Header().Get("Content-Type"))
+}
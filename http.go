@@ -21,6 +21,7 @@
 package problem
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
@@ -34,10 +35,17 @@ import (
 type WriteOptions struct {
 	// ContentType is the content/media type to be used in the HTTP response.
 	//
-	// The value will be ignored if unsupported or not appropriate for the function called. If empty,
-	// Generator.ContentType will be used with a fallback to either ContentTypeJSONUTF8 or a more appropriate
-	// content/media type depending on the function called.
+	// The value will be ignored if unsupported or not appropriate for the function called. If empty, a content/media
+	// type negotiated using the Accept header of the HTTP request (see Generator.Negotiation) or Generator.ContentType
+	// will be used with a fallback to either ContentTypeJSONUTF8 or a more appropriate content/media type depending on
+	// the function called. If not empty, no negotiation takes place.
 	ContentType string
+	// Header contains HTTP response headers to be written along with the Problem.
+	//
+	// The values of each key take precedence over those of the same key within Problem.Header, which in turn take
+	// precedence over any headers derived from the Problem (e.g. Retry-After). The Content-Type header is always derived
+	// from ContentType and any values for it are ignored. If empty, only the headers of the Problem are written.
+	Header http.Header
 	// LogArgs contains arguments to be passed to Generator.LogContext along with the Problem.
 	//
 	// If empty, no additional arguments will be passed.
@@ -72,6 +80,7 @@ const (
 // The fields of any WriteOptions found are handled as follows:
 //
 //   - ContentType is applied if not empty and valid (based on function provided)
+//   - Header is applied if not empty
 //   - LogArgs is applied if not empty
 //   - LogDisabled is always applied as only a true value changes anything
 //   - LogMessage is applied if not empty
@@ -86,6 +95,9 @@ func (wo WriteOptions) apply(opts []WriteOptions, isValidCT func(ct string) bool
 		if _opts.ContentType != "" && isValidCT(_opts.ContentType) {
 			wo.ContentType = _opts.ContentType
 		}
+		if len(_opts.Header) > 0 {
+			wo.Header = _opts.Header
+		}
 		wo.LogDisabled = _opts.LogDisabled
 		if len(_opts.LogArgs) > 0 {
 			wo.LogArgs = _opts.LogArgs
@@ -108,15 +120,19 @@ func (wo WriteOptions) apply(opts []WriteOptions, isValidCT func(ct string) bool
 }
 
 // WriteError writes an HTTP response for a Problem where the Problem is unwrapped from err, where possible, with the
-// given function being used to provide a default Problem, relying solely on WriteOptions.ContentType to determine how
-// the response is formed, with a graceful fallback to Generator.ContentType and ContentTypeJSONUTF8. WriteOptions can
-// also be passed for more granular control.
+// given function being used to provide a default Problem, relying on WriteOptions.ContentType to determine how the
+// response is formed, with a graceful fallback to a content/media type negotiated using the Accept header of req (see
+// Generator.Negotiation), Generator.ContentType, and ContentTypeJSONUTF8. WriteOptions can also be passed for more
+// granular control.
+//
+// If err is matched by Generator.Mapper, the mapped Problem is used instead of calling the function, in which case the
+// function may be nil. Otherwise, if the function is nil, a Problem wrapping err is used.
 //
 // An error is returned if the Problem fails to be written to w.
 func (g *Generator) WriteError(err error, w http.ResponseWriter, req *http.Request, probFunc func(err error) *Problem, opts ...WriteOptions) error {
-	prob, isProblem := As(err)
+	prob, isProblem := g.as(err)
 	if !isProblem {
-		prob = probFunc(err)
+		prob = g.errorProblem(req.Context(), err, probFunc)
 	}
 	return g.WriteProblem(prob, w, req, opts...)
 }
@@ -125,11 +141,14 @@ func (g *Generator) WriteError(err error, w http.ResponseWriter, req *http.Reque
 // possible, with the given function being used to provide a default Problem. WriteOptions can also be passed for more
 // granular control.
 //
+// If err is matched by Generator.Mapper, the mapped Problem is used instead of calling the function, in which case the
+// function may be nil. Otherwise, if the function is nil, a Problem wrapping err is used.
+//
 // An error is returned if the Problem fails to be written to w.
 func (g *Generator) WriteErrorJSON(err error, w http.ResponseWriter, req *http.Request, probFunc func(err error) *Problem, opts ...WriteOptions) error {
-	prob, isProblem := As(err)
+	prob, isProblem := g.as(err)
 	if !isProblem {
-		prob = probFunc(err)
+		prob = g.errorProblem(req.Context(), err, probFunc)
 	}
 	return g.WriteProblemJSON(prob, w, req, opts...)
 }
@@ -138,22 +157,33 @@ func (g *Generator) WriteErrorJSON(err error, w http.ResponseWriter, req *http.R
 // possible, with the given function being used to provide a default Problem. WriteOptions can also be passed for more
 // granular control.
 //
+// If err is matched by Generator.Mapper, the mapped Problem is used instead of calling the function, in which case the
+// function may be nil. Otherwise, if the function is nil, a Problem wrapping err is used.
+//
 // An error is returned if the Problem fails to be written to w.
 func (g *Generator) WriteErrorXML(err error, w http.ResponseWriter, req *http.Request, probFunc func(err error) *Problem, opts ...WriteOptions) error {
-	prob, isProblem := As(err)
+	prob, isProblem := g.as(err)
 	if !isProblem {
-		prob = probFunc(err)
+		prob = g.errorProblem(req.Context(), err, probFunc)
 	}
 	return g.WriteProblemXML(prob, w, req, opts...)
 }
 
 // WriteProblem writes an HTTP response for the given Problem, optionally using WriteOptions for more granular control,
-// relying solely on WriteOptions.ContentType to determine how the response is formed, with a graceful fallback to
-// Generator.ContentType and ContentTypeJSONUTF8.
+// relying on WriteOptions.ContentType to determine how the response is formed, with a graceful fallback to a
+// content/media type negotiated using the Accept header of req (see Generator.Negotiation), Generator.ContentType, and
+// ContentTypeJSONUTF8.
+//
+// If Generator.Negotiation is NegotiationStrict and none of the supported content/media types are acceptable, an HTTP
+// response for a Problem describing the failed negotiation is written instead, however, prob is still logged.
 //
 // An error is returned if prob fails to be written to w.
 func (g *Generator) WriteProblem(prob *Problem, w http.ResponseWriter, req *http.Request, opts ...WriteOptions) error {
-	return g.writeProblem(prob, w, req, WriteOptions{ContentType: g.contentType()}.apply(opts, isValidContentType))
+	_opts, acceptable := g.negotiateWriteOptions(w, req, WriteOptions{ContentType: g.contentType()}, opts)
+	if !acceptable {
+		return g.writeNotAcceptable(prob, w, req, _opts)
+	}
+	return g.writeProblem(prob, w, req, _opts)
 }
 
 // WriteProblemJSON writes an HTTP response for the given Problem in JSON format, optionally using WriteOptions for more
@@ -172,6 +202,16 @@ func (g *Generator) WriteProblemXML(prob *Problem, w http.ResponseWriter, req *h
 	return g.writeProblemXML(prob, w, req, WriteOptions{ContentType: ContentTypeXMLUTF8}.apply(opts, isValidContentTypeForXML))
 }
 
+// errorProblem returns a Problem for the given error that is not a Problem, using a Problem mapped from err by
+// Generator.Mapper, if possible, otherwise a Problem returned by probFunc. If probFunc is nil, a Problem wrapping err is
+// returned.
+func (g *Generator) errorProblem(ctx context.Context, err error, probFunc func(err error) *Problem) *Problem {
+	if probFunc == nil || g.Mapper.Matches(err) {
+		return g.new(ctx, []Option{Wrap(err)}, 1)
+	}
+	return probFunc(err)
+}
+
 // writeProblem writes an HTTP response for the given Problem using WriteOptions, that are expected to have been
 // applied, to determine how the response is formed and whether the Problem is logged.
 //
@@ -179,11 +219,13 @@ func (g *Generator) WriteProblemXML(prob *Problem, w http.ResponseWriter, req *h
 //
 // Panics if WriteOptions.ContentType is not recognized.
 func (g *Generator) writeProblem(prob *Problem, w http.ResponseWriter, req *http.Request, opts WriteOptions) error {
-	switch opts.ContentType {
-	case ContentTypeJSON, ContentTypeJSONUTF8:
+	switch {
+	case isValidContentTypeForJSON(opts.ContentType):
 		return g.writeProblemJSON(prob, w, req, opts)
-	case ContentTypeXML, ContentTypeXMLUTF8:
+	case isValidContentTypeForXML(opts.ContentType):
 		return g.writeProblemXML(prob, w, req, opts)
+	case isValidContentTypeForHTML(opts.ContentType):
+		return g.writeProblemHTML(prob, w, req, opts)
 	default:
 		// Sanity check - should never happen
 		panic(fmt.Errorf("unexpected WriteOptions.ContentType applied: %q", opts.ContentType))
@@ -193,14 +235,18 @@ func (g *Generator) writeProblem(prob *Problem, w http.ResponseWriter, req *http
 // writeProblemJSON writes an HTTP response for the given Problem in JSON format using WriteOptions, that are expected
 // to have been applied, to determine how the response is formed and whether the Problem is logged.
 //
+// The Problem is localized in the language negotiated for the response, if any (see Generator.Languages), and redacted
+// using Generator.Redactor, if any, after it has been logged.
+//
 // An error is returned if prob fails to be written to w.
 func (g *Generator) writeProblemJSON(prob *Problem, w http.ResponseWriter, req *http.Request, opts WriteOptions) error {
+	ctx := g.languageContext(w, req)
 	if !opts.LogDisabled && opts.LogMessage != "" {
-		g.LogContext(req.Context(), opts.LogMessage, prob, opts.LogArgs...)
+		g.LogContext(req.Context(), opts.LogMessage, g.localizeForLog(req.Context(), prob), opts.LogArgs...)
 	}
+	prob = g.redactResponse(ctx, g.localizeResponse(ctx, w, prob), responseStatus(prob, opts))
 
-	w.Header().Set(contentTypeHeader, opts.ContentType)
-	w.WriteHeader(firstNonZeroValue(opts.Status, prob.Status, http.StatusInternalServerError))
+	writeHeader(w, prob, opts)
 
 	return json.NewEncoder(w).Encode(prob)
 }
@@ -208,18 +254,45 @@ func (g *Generator) writeProblemJSON(prob *Problem, w http.ResponseWriter, req *
 // writeProblemXML writes an HTTP response for the given Problem in XML format using WriteOptions, that are expected to
 // have been applied, to determine how the response is formed and whether the Problem is logged.
 //
+// The Problem is localized in the language negotiated for the response, if any (see Generator.Languages), and redacted
+// using Generator.Redactor, if any, after it has been logged.
+//
 // An error is returned if prob fails to be written to w.
 func (g *Generator) writeProblemXML(prob *Problem, w http.ResponseWriter, req *http.Request, opts WriteOptions) error {
+	ctx := g.languageContext(w, req)
 	if !opts.LogDisabled && opts.LogMessage != "" {
-		g.LogContext(req.Context(), opts.LogMessage, prob, opts.LogArgs...)
+		g.LogContext(req.Context(), opts.LogMessage, g.localizeForLog(req.Context(), prob), opts.LogArgs...)
 	}
+	prob = g.redactResponse(ctx, g.localizeResponse(ctx, w, prob), responseStatus(prob, opts))
 
-	w.Header().Set(contentTypeHeader, opts.ContentType)
-	w.WriteHeader(firstNonZeroValue(opts.Status, prob.Status, http.StatusInternalServerError))
+	writeHeader(w, prob, opts)
 
 	return xml.NewEncoder(w).Encode(prob)
 }
 
+// writeHeader writes the header and status code of an HTTP response for the given Problem using WriteOptions, that are
+// expected to have been applied.
+//
+// If prob has a retry delay, it is included in the Retry-After header. See Problem.RetryAfter for more information.
+// Afterward, the headers of prob are written followed by WriteOptions.Header, with the values of each key replacing any
+// existing values. The Content-Type header is always written last so that it cannot be overridden.
+func writeHeader(w http.ResponseWriter, prob *Problem, opts WriteOptions) {
+	header := w.Header()
+	if v := prob.retry.header(); v != "" {
+		header.Set(retryAfterHeader, v)
+	}
+	mergeHeader(header, prob.header)
+	mergeHeader(header, opts.Header)
+	header.Set(contentTypeHeader, opts.ContentType)
+	w.WriteHeader(responseStatus(prob, opts))
+}
+
+// responseStatus returns the status code of an HTTP response for the given Problem using WriteOptions, that are
+// expected to have been applied.
+func responseStatus(prob *Problem, opts WriteOptions) int {
+	return firstNonZeroValue(opts.Status, prob.Status, http.StatusInternalServerError)
+}
+
 // Middleware is a convenient shorthand for calling MiddlewareUsing with DefaultGenerator.
 func Middleware(probFunc func(err error) *Problem, opts ...WriteOptions) func(http.Handler) http.Handler {
 	return MiddlewareUsing(nil, probFunc, opts...)
@@ -230,7 +303,11 @@ func Middleware(probFunc func(err error) *Problem, opts ...WriteOptions) func(ht
 // recovered values to be used to form Problem HTTP responses, optionally using WriteOptions for more granular control.
 //
 // If a value recovered from a panic is not a Problem (which is highly likely), probFunc is called with an error
-// representation of that value (if not already an error) to be used to construct a Problem.
+// representation of that value (if not already an error) to be used to construct a Problem. However, if the error is
+// matched by Generator.Mapper, the mapped Problem is used instead, and probFunc may be nil.
+//
+// The content/media type of any Problem HTTP response may be negotiated using the Accept header of the HTTP request
+// (see Generator.Negotiation).
 func MiddlewareUsing(gen *Generator, probFunc func(err error) *Problem, opts ...WriteOptions) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -243,20 +320,24 @@ func MiddlewareUsing(gen *Generator, probFunc func(err error) *Problem, opts ...
 			defer func() {
 				if r := recover(); r != nil {
 					var prob *Problem
-					_opts := WriteOptions{
+					_opts, acceptable := gen.negotiateWriteOptions(w, req, WriteOptions{
 						ContentType: gen.contentType(),
 						LogMessage:  defaultHTTPPanicLogMessage,
-					}.apply(opts, isValidContentType)
+					}, opts)
 					if err, isErr := r.(error); isErr && err != nil {
 						var isProblem bool
-						prob, isProblem = As(err)
+						prob, isProblem = gen.as(err)
 						if !isProblem {
-							prob = probFunc(err)
+							prob = gen.errorProblem(req.Context(), err, probFunc)
 						}
 					} else {
-						prob = probFunc(fmt.Errorf("%v", r))
+						prob = gen.errorProblem(req.Context(), fmt.Errorf("%v", r), probFunc)
+					}
+					if acceptable {
+						_ = gen.writeProblem(prob, w, req, _opts)
+					} else {
+						_ = gen.writeNotAcceptable(prob, w, req, _opts)
 					}
-					_ = gen.writeProblem(prob, w, req, _opts)
 				}
 			}()
 
@@ -0,0 +1,159 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package http
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/jay-babu/go-problem"
+)
+
+// Classifier is used to classify errors that are not problems into a problem.Problem using a problem.Definition
+// within this package, allowing it to be passed directly as the function used to provide a default problem.Problem
+// to problem.WriteError, problem.Middleware, and similar.
+//
+// A Classifier matches errors using the same problem.MapperRule as a problem.Mapper, so rules can be shared
+// between the two. For example;
+//
+//	classifier := http.NewClassifier(
+//		problem.MapIs(ErrAccountLocked, http.LockedDefinition),
+//	)
+//	handler := problem.Middleware(classifier.Classify)(mux)
+//
+// The zero value is ready to use but contains no rules so will classify every error using Fallback.
+type Classifier struct {
+	// Fallback is the problem.Definition used to construct a problem.Problem for an error that is not classified by
+	// any of the Rules.
+	//
+	// If Fallback is zero, InternalServerDefinition is used.
+	Fallback problem.Definition
+	// Generator is the problem.Generator to be used to construct a problem.Problem.
+	//
+	// If Generator is nil, problem.DefaultGenerator is used.
+	Generator *problem.Generator
+	// Rules contains the problem.MapperRule used to classify errors, with the first one to match an error being
+	// used.
+	Rules []problem.MapperRule
+}
+
+// DefaultClassifier is the Classifier used by Classify and contains only DefaultClassifierRules.
+var DefaultClassifier = NewClassifier()
+
+// Classify is a convenient shorthand for calling Classifier.Classify on DefaultClassifier.
+func Classify(err error) *problem.Problem {
+	return DefaultClassifier.Classify(err)
+}
+
+// DefaultClassifierRules returns the built-in problem.MapperRule used to classify common errors from the standard
+// library, which are as follows:
+//   - context.Canceled is classified using ClientClosedRequestDefinition
+//   - context.DeadlineExceeded is classified using GatewayTimeoutDefinition
+//   - fs.ErrNotExist (including os.ErrNotExist) is classified using NotFoundDefinition
+//   - fs.ErrPermission (including os.ErrPermission) is classified using ForbiddenDefinition
+//   - sql.ErrNoRows is classified using NotFoundDefinition
+//   - *http.MaxBytesError is classified using RequestEntityTooLargeDefinition with a "limit" extension
+//   - *json.SyntaxError is classified using BadRequestDefinition with an "offset" extension
+//   - *json.UnmarshalTypeError is classified using BadRequestDefinition with a "field" extension, if known, otherwise
+//     an "offset" extension
+//   - net.Error is classified using GatewayTimeoutDefinition, but only if it is a timeout
+//
+// As they are problem.MapperRule, they can also be used within a problem.Mapper. For example;
+//
+//	gen := &problem.Generator{Mapper: problem.NewMapper(http.DefaultClassifierRules()...)}
+func DefaultClassifierRules() []problem.MapperRule {
+	return []problem.MapperRule{
+		problem.MapIs(context.Canceled, ClientClosedRequestDefinition),
+		problem.MapIs(context.DeadlineExceeded, GatewayTimeoutDefinition),
+		problem.MapIs(fs.ErrNotExist, NotFoundDefinition),
+		problem.MapIs(fs.ErrPermission, ForbiddenDefinition),
+		problem.MapIs(sql.ErrNoRows, NotFoundDefinition),
+		problem.MapAs[*http.MaxBytesError](RequestEntityTooLargeDefinition,
+			problem.MapExtensionFromError("limit", func(err error) any {
+				return err.(*http.MaxBytesError).Limit
+			}),
+		),
+		problem.MapAs[*json.SyntaxError](BadRequestDefinition,
+			problem.MapExtensionFromError("offset", func(err error) any {
+				return err.(*json.SyntaxError).Offset
+			}),
+		),
+		problem.MapAs[*json.UnmarshalTypeError](BadRequestDefinition, func(err error) []problem.Option {
+			typeErr := err.(*json.UnmarshalTypeError)
+			if typeErr.Field != "" {
+				return []problem.Option{problem.WithExtension("field", typeErr.Field)}
+			}
+			return []problem.Option{problem.WithExtension("offset", typeErr.Offset)}
+		}),
+		problem.MapFunc(func(err error) bool {
+			var netErr net.Error
+			return errors.As(err, &netErr) && netErr.Timeout()
+		}, GatewayTimeoutDefinition),
+	}
+}
+
+// NewClassifier returns a Classifier containing the given rules followed by DefaultClassifierRules, allowing the rules
+// provided to take precedence.
+func NewClassifier(rules ...problem.MapperRule) *Classifier {
+	return &Classifier{Rules: slices.Concat(rules, DefaultClassifierRules())}
+}
+
+// Classify returns a problem.Problem wrapping the given error that has been classified by the first of
+// Classifier.Rules to match it, otherwise Classifier.Fallback is used. The signature of Classify allows it to be passed
+// directly as the function used to provide a default problem.Problem (e.g. to problem.WriteError or
+// problem.Middleware).
+//
+// If err is already a problem.Problem, or its tree contains one, it is returned instead. nil is returned if err is
+// nil.
+func (c *Classifier) Classify(err error) *problem.Problem {
+	if err == nil {
+		return nil
+	}
+	if prob, isProblem := problem.As(err); isProblem {
+		return prob
+	}
+
+	opts := []problem.Option{problem.Wrap(err)}
+	if mapped, classified := (&problem.Mapper{Rules: c.Rules}).Map(err); classified {
+		opts = append(opts, mapped...)
+	} else {
+		opts = append(opts, problem.FromDefinition(c.fallback()))
+	}
+	if gen := c.Generator; gen != nil {
+		return gen.New(opts...)
+	}
+	return problem.New(opts...)
+}
+
+// fallback returns Classifier.Fallback if not zero, otherwise InternalServerDefinition.
+func (c *Classifier) fallback() problem.Definition {
+	if !reflect.ValueOf(c.Fallback).IsZero() {
+		return c.Fallback
+	}
+	return InternalServerDefinition
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package http_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	problemhttp "github.com/jay-babu/go-problem/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestClassify(t *testing.T) {
+	testCases := map[string]struct {
+		err    error
+		status int
+	}{
+		"context canceled":  {err: fmt.Errorf("query: %w", context.Canceled), status: problemhttp.StatusClientClosedRequest},
+		"deadline exceeded": {err: context.DeadlineExceeded, status: http.StatusGatewayTimeout},
+		"not exist":         {err: &os.PathError{Op: "open", Path: "x", Err: os.ErrNotExist}, status: http.StatusNotFound},
+		"permission":        {err: os.ErrPermission, status: http.StatusForbidden},
+		"no rows":           {err: fmt.Errorf("find user: %w", sql.ErrNoRows), status: http.StatusNotFound},
+		"max bytes":         {err: &http.MaxBytesError{Limit: 1024}, status: http.StatusRequestEntityTooLarge},
+		"net timeout":       {err: timeoutError{}, status: http.StatusGatewayTimeout},
+		"unknown":           {err: errors.New("boom"), status: http.StatusInternalServerError},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			prob := problemhttp.Classify(tc.err)
+			require.NotNil(t, prob)
+			assert.Equal(t, tc.status, prob.Status)
+			assert.ErrorIs(t, prob, tc.err)
+		})
+	}
+}
+
+func TestClassify_JSON(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+	err := json.Unmarshal([]byte(`{"name":1}`), &v)
+	prob := problemhttp.Classify(err)
+	assert.Equal(t, http.StatusBadRequest, prob.Status)
+	assert.Equal(t, "name", prob.Extensions["field"])
+
+	err = json.Unmarshal([]byte(`{"name":`), &v)
+	prob = problemhttp.Classify(err)
+	assert.Equal(t, http.StatusBadRequest, prob.Status)
+	assert.Contains(t, prob.Extensions, "offset")
+
+	err = json.NewDecoder(strings.NewReader(`{"name" "x"}`)).Decode(&v)
+	prob = problemhttp.Classify(err)
+	assert.Equal(t, http.StatusBadRequest, prob.Status)
+	assert.Equal(t, int64(9), prob.Extensions["offset"])
+}
+
+func TestClassifier_Custom(t *testing.T) {
+	errLocked := errors.New("account locked")
+	classifier := problemhttp.NewClassifier(
+		problem.MapIs(errLocked, problemhttp.LockedDefinition),
+		problem.MapIs(sql.ErrNoRows, problemhttp.GoneDefinition),
+	)
+	classifier.Fallback = problemhttp.ServiceUnavailableDefinition
+
+	assert.Equal(t, http.StatusLocked, classifier.Classify(errLocked).Status)
+	assert.Equal(t, http.StatusGone, classifier.Classify(sql.ErrNoRows).Status)
+	assert.Equal(t, http.StatusGatewayTimeout, classifier.Classify(context.DeadlineExceeded).Status)
+	assert.Equal(t, http.StatusServiceUnavailable, classifier.Classify(errors.New("boom")).Status)
+	assert.Nil(t, classifier.Classify(nil))
+
+	prob := problemhttp.NotFoundDefinition.New()
+	assert.Same(t, prob, classifier.Classify(fmt.Errorf("wrapped: %w", prob)))
+}
+
+func TestDefaultClassifierRules_Mapper(t *testing.T) {
+	gen := &problem.Generator{Mapper: problem.NewMapper(problemhttp.DefaultClassifierRules()...)}
+
+	prob := gen.New(problem.Wrap(fmt.Errorf("find user: %w", sql.ErrNoRows)))
+	assert.Equal(t, http.StatusNotFound, prob.Status)
+	assert.True(t, problem.IsDefinition(prob, problemhttp.NotFoundDefinition))
+
+	prob = gen.New(problem.Wrap(&http.MaxBytesError{Limit: 10}))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, prob.Status)
+	assert.Equal(t, int64(10), prob.Extensions["limit"])
+}
+
+func TestClassifier_Middleware(t *testing.T) {
+	handler := problem.Middleware(problemhttp.Classify)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(&http.MaxBytesError{Limit: 10})
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, float64(10), body["limit"])
+}
@@ -41,6 +41,13 @@ var (
 		Type:      BadRequest,
 	}
 
+	// ClientClosedRequestDefinition is a built-in reusable problem.Definition that may be used to represent a
+	// non-standard HTTP Client Closed Request error.
+	ClientClosedRequestDefinition = problem.Definition{
+		DetailKey: "problem.http.ClientClosedRequestDefinition.detail",
+		Type:      ClientClosedRequest,
+	}
+
 	// ConflictDefinition is a built-in reusable problem.Definition that may be used to represent an HTTP Conflict
 	// error.
 	ConflictDefinition = problem.Definition{
@@ -387,6 +394,8 @@ func StatusDefinitionOrElse(code int, defaultDefinition problem.Definition) prob
 		return RequestHeaderFieldsTooLargeDefinition
 	case http.StatusUnavailableForLegalReasons:
 		return UnavailableForLegalReasonsDefinition
+	case StatusClientClosedRequest:
+		return ClientClosedRequestDefinition
 	case http.StatusInternalServerError:
 		return InternalServerDefinition
 	case http.StatusNotImplemented:
@@ -23,9 +23,13 @@ package http
 import (
 	"net/http"
 
-	"github.com/neocotic/go-problem"
+	"github.com/jay-babu/go-problem"
 )
 
+// StatusClientClosedRequest is the non-standard HTTP status code, originally introduced by nginx, used to indicate that
+// the client closed the connection before the server could respond.
+const StatusClientClosedRequest = 499
+
 var (
 	// BadGateway is a built-in reusable problem.Type that may be used to represent an HTTP Bad Gateway error.
 	BadGateway = problem.Type{
@@ -43,6 +47,15 @@ var (
 		TitleKey: "problem.http.BadRequest.title",
 	}
 
+	// ClientClosedRequest is a built-in reusable problem.Type that may be used to represent a non-standard HTTP Client
+	// Closed Request error.
+	ClientClosedRequest = problem.Type{
+		LogLevel: problem.LogLevelDebug,
+		Status:   StatusClientClosedRequest,
+		Title:    "Client Closed Request",
+		TitleKey: "problem.http.ClientClosedRequest.title",
+	}
+
 	// Conflict is a built-in reusable problem.Type that may be used to represent an HTTP Conflict error.
 	Conflict = problem.Type{
 		LogLevel: problem.LogLevelDebug,
@@ -283,11 +296,14 @@ var (
 
 	// ServiceUnavailable is a built-in reusable problem.Type that may be used to represent an HTTP Service Unavailable
 	// error.
+	//
+	// It is retryable as the condition is typically temporary.
 	ServiceUnavailable = problem.Type{
-		LogLevel: problem.LogLevelError,
-		Status:   http.StatusServiceUnavailable,
-		Title:    http.StatusText(http.StatusServiceUnavailable),
-		TitleKey: "problem.http.ServiceUnavailable.title",
+		LogLevel:  problem.LogLevelError,
+		Retryable: true,
+		Status:    http.StatusServiceUnavailable,
+		Title:     http.StatusText(http.StatusServiceUnavailable),
+		TitleKey:  "problem.http.ServiceUnavailable.title",
 	}
 
 	// Teapot is a built-in reusable problem.Type that may be used to represent an HTTP I'm a teapot error.
@@ -308,11 +324,14 @@ var (
 
 	// TooManyRequests is a built-in reusable problem.Type that may be used to represent an HTTP Too Many Requests
 	// error.
+	//
+	// It is retryable as the request may succeed once the rate limit has been reset.
 	TooManyRequests = problem.Type{
-		LogLevel: problem.LogLevelWarn,
-		Status:   http.StatusTooManyRequests,
-		Title:    http.StatusText(http.StatusTooManyRequests),
-		TitleKey: "problem.http.TooManyRequests.title",
+		LogLevel:  problem.LogLevelWarn,
+		Retryable: true,
+		Status:    http.StatusTooManyRequests,
+		Title:     http.StatusText(http.StatusTooManyRequests),
+		TitleKey:  "problem.http.TooManyRequests.title",
 	}
 
 	// Unauthorized is a built-in reusable problem.Type that may be used to represent an HTTP Unauthorized error.
@@ -447,6 +466,8 @@ func StatusTypeOrElse(code int, defaultType problem.Type) problem.Type {
 		return RequestHeaderFieldsTooLarge
 	case http.StatusUnavailableForLegalReasons:
 		return UnavailableForLegalReasons
+	case StatusClientClosedRequest:
+		return ClientClosedRequest
 	case http.StatusInternalServerError:
 		return InternalServer
 	case http.StatusNotImplemented:
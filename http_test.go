@@ -0,0 +1,72 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteProblem_Header(t *testing.T) {
+	def := problem.Definition{
+		Header: http.Header{"cache-control": {"no-store"}, "Www-Authenticate": {`Bearer realm="example"`}},
+		Type:   problem.Type{Status: http.StatusUnauthorized},
+	}
+	prob := def.New(
+		problem.WithHeader("WWW-Authenticate", `Bearer realm="example", error="invalid_token"`),
+		problem.WithHeader("Link", `<https://example.com/probs>; rel="help"`),
+		problem.WithRetryAfter(time.Second),
+	)
+	assert.Equal(t, http.Header{
+		"Cache-Control":    {"no-store"},
+		"Link":             {`<https://example.com/probs>; rel="help"`},
+		"Www-Authenticate": {`Bearer realm="example", error="invalid_token"`},
+	}, prob.Header())
+
+	b, err := prob.MarshalJSON()
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "no-store")
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, problem.WriteProblem(prob, rec, req, problem.WriteOptions{
+		Header: http.Header{
+			"Content-Language": {"en"},
+			"Content-Type":     {"text/plain"},
+			"Link":             {`<https://example.com/docs>; rel="help"`},
+			"Retry-After":      {"5"},
+		},
+		LogDisabled: true,
+	}))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "en", rec.Header().Get("Content-Language"))
+	assert.Equal(t, problem.ContentTypeJSONUTF8, rec.Header().Get("Content-Type"))
+	assert.Equal(t, `<https://example.com/docs>; rel="help"`, rec.Header().Get("Link"))
+	assert.Equal(t, "5", rec.Header().Get("Retry-After"))
+	assert.Equal(t, `Bearer realm="example", error="invalid_token"`, rec.Header().Get("WWW-Authenticate"))
+}
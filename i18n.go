@@ -20,7 +20,29 @@
 
 package problem
 
-import "context"
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/jay-babu/go-problem/internal/accept"
+)
+
+// translationKeys contains the translation keys from which the localized fields of a Problem were resolved.
+type translationKeys struct {
+	// detail is the translation key from which Problem.Detail was resolved, if any.
+	detail any
+	// title is the translation key from which Problem.Title was resolved, if any.
+	title any
+}
+
+const (
+	// acceptLanguageHeader is the header representing the languages acceptable to the client of an HTTP request.
+	acceptLanguageHeader = "Accept-Language"
+	// contentLanguageHeader is the header representing the language of an HTTP response's content.
+	contentLanguageHeader = "Content-Language"
+)
 
 // Translator is a function that returns a localized value based on the translation key provided.
 //
@@ -48,3 +70,116 @@ func (g *Generator) translateOrElse(ctx context.Context, key any, defaultValue s
 	}
 	return defaultValue
 }
+
+// Localize returns the given Problem with its title, detail, and the detail of each FieldError localized again using
+// Generator.Translator against the context.Context provided, where possible, based on the translation keys from which
+// they were resolved when the Problem was constructed. The detail visible when the Problem is logged (see
+// Problem.LogInfo) is also localized, even if hidden from Problem.Detail.
+//
+// This allows a Problem constructed without any knowledge of the language of the client (e.g. deep within a service
+// layer using context.Background) to be localized later, typically in combination with UsingLanguage. Problems written
+// to HTTP responses by the Generator are localized automatically (see Generator.Languages for more information).
+//
+// As when a Problem is constructed, the context.Context passed to Generator.Translator contains the extensions of the
+// Problem (see GetTranslationExtensions for more information).
+//
+// A value is only replaced if it is not empty and a localized value could be found for its translation key. Since the
+// translation keys are retained internally, explicitly changing Problem.Title or Problem.Detail after the Problem was
+// constructed may result in them being replaced. Problems that were decoded (e.g. via Problem.UnmarshalJSON) have no
+// translation keys.
+//
+// If Generator.Translator is nil or no value could be localized, prob is returned unchanged. Otherwise, a localized
+// clone of prob is returned and prob is never modified.
+func (g *Generator) Localize(ctx context.Context, prob *Problem) *Problem {
+	localized, _ := g.localize(ctx, prob)
+	return localized
+}
+
+// localize effectively does the heavy lifting for Generator.Localize but also returns whether any value was localized.
+func (g *Generator) localize(ctx context.Context, prob *Problem) (*Problem, bool) {
+	if g.Translator == nil || prob == nil {
+		return prob, false
+	}
+	c := prob.Clone()
+	ctx = usingTranslationExtensions(ctx, c.Extensions)
+	var localized bool
+	c.Detail = g.localizeValue(ctx, c.keys.detail, c.Detail, &localized)
+	c.Title = g.localizeValue(ctx, c.keys.title, c.Title, &localized)
+	c.logInfo.Detail = g.localizeValue(ctx, c.keys.detail, c.logInfo.Detail, &localized)
+	if fieldErrs, ok := c.Extensions[ValidationErrorsExtensionKey].([]FieldError); ok {
+		fieldErrs = slices.Clone(fieldErrs)
+		for i, fe := range fieldErrs {
+			fieldErrs[i].Detail = g.localizeValue(ctx, fe.DetailKey, fe.Detail, &localized)
+		}
+		c.Extensions[ValidationErrorsExtensionKey] = fieldErrs
+	}
+	if !localized {
+		return prob, false
+	}
+	return c, true
+}
+
+// localizeValue returns the localized value for the given translation key using Generator.Translator, provided that
+// neither key nor value are empty and a localized value could be found, in which case localized is set to true.
+// Otherwise, value is returned.
+func (g *Generator) localizeValue(ctx context.Context, key any, value string, localized *bool) string {
+	if key == nil || value == "" {
+		return value
+	}
+	if v := g.Translator(ctx, key); v != "" {
+		*localized = true
+		return v
+	}
+	return value
+}
+
+// localizeResponse returns the given Problem localized using Generator.Localize against the context.Context provided,
+// which is expected to have been returned by Generator.languageContext, while also populating the Content-Language
+// header of the response with the language within ctx, but only if any value was actually localized.
+func (g *Generator) localizeResponse(ctx context.Context, w http.ResponseWriter, prob *Problem) *Problem {
+	prob, localized := g.localize(ctx, prob)
+	if lang := GetLanguage(ctx); localized && lang != "" {
+		w.Header().Set(contentLanguageHeader, lang)
+	}
+	return prob
+}
+
+// languageContext returns a copy of the context.Context of the given HTTP request containing the language negotiated
+// for its response, if any. Otherwise, the context.Context of the request is returned unchanged. The Content-Language
+// header of the response is left to Generator.localizeResponse so that it is only populated when a Problem is actually
+// localized.
+//
+// Any language tag already within the context.Context of the request (see UsingLanguage) takes precedence. Otherwise,
+// the language is negotiated from Generator.Languages using the Accept-Language header of the request, falling back on
+// the first of Generator.Languages if none are acceptable.
+func (g *Generator) languageContext(w http.ResponseWriter, req *http.Request) context.Context {
+	ctx := req.Context()
+	if GetLanguage(ctx) != "" || len(g.Languages) == 0 {
+		return ctx
+	}
+	if !slices.Contains(w.Header().Values(varyHeader), acceptLanguageHeader) {
+		w.Header().Add(varyHeader, acceptLanguageHeader)
+	}
+
+	lang, acceptable := accept.NegotiateLanguage(strings.Join(req.Header.Values(acceptLanguageHeader), ","), g.Languages)
+	if !acceptable {
+		lang = g.Languages[0]
+	}
+	return UsingLanguage(ctx, lang)
+}
+
+// localizeForLog returns the given Problem localized in the default language (i.e. the first of Generator.Languages) so
+// that logs are not affected by the language of each client. If Generator.Languages is empty, prob is returned
+// unchanged.
+func (g *Generator) localizeForLog(ctx context.Context, prob *Problem) *Problem {
+	if len(g.Languages) == 0 {
+		return prob
+	}
+	return g.Localize(UsingLanguage(ctx, g.Languages[0]), prob)
+}
+
+// Localize is a convenient shorthand for calling Generator.Localize on the Generator within the given context.Context,
+// if any, otherwise DefaultGenerator.
+func Localize(ctx context.Context, prob *Problem) *Problem {
+	return GetGenerator(ctx).Localize(ctx, prob)
+}
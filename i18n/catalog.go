@@ -0,0 +1,274 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package i18n provides a problem.Translator backed by a catalog of localized messages loaded from JSON and/or YAML
+// files, along with middleware to negotiate the language of each HTTP request using its Accept-Language header.
+//
+// Each message file contains the messages for a single language, named after its language tag (e.g. "fr-CA.json"),
+// where each message is mapped to its translation key. Messages may contain "{name}" placeholders that are filled using
+// the extensions of the problem being localized. For example;
+//
+//	# fr.yaml
+//	problem.http.NotFound.title: Introuvable
+//	credit.detail: Votre solde actuel est de {balance}
+//
+// Default returns a Catalog containing English, French, German, and Spanish messages for every translation key used by
+// the http package, as well as problem.DefaultRedactedDetailKey, which can be combined with any other catalog using
+// Catalog.Merge. For example;
+//
+//	msgs, err := i18n.LoadFS(os.DirFS("messages"))
+//	// ...
+//	cat := i18n.Default().Merge(msgs)
+//	gen := &problem.Generator{Languages: cat.Languages(), Translator: cat.Translator()}
+//	handler := cat.Middleware()(problem.MiddlewareUsing(gen, nil)(mux))
+package i18n
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/jay-babu/go-problem/internal/accept"
+)
+
+// Catalog is a lookup table of localized messages mapped to translation keys for each language.
+//
+// When looking up a message for a language, the messages of more general languages are used as a fallback (e.g. "fr"
+// for "fr-CA"), followed by those of Catalog.Fallback. For example, a message for "fr-CA" is looked up within "fr-CA",
+// "fr", and then "en", by default.
+//
+// A Catalog is safe for concurrent use.
+type Catalog struct {
+	// Fallback is the language tag of the language whose messages are used when a message cannot be found for the
+	// requested language. It is also the language used when none of the languages within the Catalog are acceptable.
+	//
+	// If empty, DefaultFallback is used.
+	Fallback string
+	// messages contains the messages mapped to translation keys for each language, where each language is mapped to its
+	// lower-cased language tag.
+	messages map[string]map[string]string
+	// tags contains the language tags within the Catalog, as declared, mapped by their lower-cased equivalent.
+	tags map[string]string
+}
+
+// DefaultFallback is the language tag of the language used as a fallback when Catalog.Fallback is empty.
+const DefaultFallback = "en"
+
+var (
+	//go:embed messages/*.json
+	defaultFS embed.FS
+
+	// defaultCatalog lazily loads the Catalog returned by Default.
+	defaultCatalog = sync.OnceValue(func() *Catalog {
+		c, err := LoadFS(defaultFS)
+		if err != nil {
+			// Sanity check - should never happen
+			panic(err)
+		}
+		return c
+	})
+)
+
+// Default returns a Catalog containing the built-in English ("en"), French ("fr"), German ("de"), and Spanish ("es")
+// messages for every translation key used by the http package, as well as problem.DefaultRedactedDetailKey.
+//
+// A new Catalog is returned for each call so that Catalog.Fallback can be changed safely.
+func Default() *Catalog {
+	c := defaultCatalog()
+	return &Catalog{messages: c.messages, tags: c.tags}
+}
+
+// Languages returns the language tags of all languages within the Catalog, as declared, with the fallback language
+// first, if present, followed by the rest in lexical order. As such, it is suitable for use as
+// problem.Generator.Languages.
+func (c *Catalog) Languages() []string {
+	fallback := strings.ToLower(c.fallback())
+	return slices.SortedFunc(maps.Values(c.tags), func(a, b string) int {
+		switch {
+		case a == b:
+			return 0
+		case strings.ToLower(a) == fallback:
+			return -1
+		case strings.ToLower(b) == fallback:
+			return 1
+		default:
+			return strings.Compare(a, b)
+		}
+	})
+}
+
+// Merge returns a new Catalog containing the messages of the Catalog along with those of the given catalogs, where the
+// messages of later catalogs take precedence over earlier ones for the same language and translation key.
+//
+// The returned Catalog has the same Catalog.Fallback as the Catalog on which Merge was called.
+func (c *Catalog) Merge(others ...*Catalog) *Catalog {
+	merged := &Catalog{
+		Fallback: c.Fallback,
+		messages: make(map[string]map[string]string, len(c.messages)),
+		tags:     maps.Clone(c.tags),
+	}
+	for _, other := range slices.Concat([]*Catalog{c}, others) {
+		if other == nil {
+			continue
+		}
+		for lang, tag := range other.tags {
+			if _, found := merged.tags[lang]; !found {
+				merged.tags[lang] = tag
+			}
+		}
+		for lang, msgs := range other.messages {
+			if merged.messages[lang] == nil {
+				merged.messages[lang] = make(map[string]string, len(msgs))
+			}
+			maps.Copy(merged.messages[lang], msgs)
+		}
+	}
+	return merged
+}
+
+// Message returns the message mapped to the given translation key for the language provided, falling back on more
+// general languages and then Catalog.Fallback, if needed. See Catalog for more information.
+//
+// The language tag is matched case-insensitively. If lang is empty, only the messages of Catalog.Fallback are used.
+func (c *Catalog) Message(lang, key string) (string, bool) {
+	for _, tag := range []string{lang, c.fallback()} {
+		for tag = strings.ToLower(tag); tag != ""; tag = parentLanguage(tag) {
+			if msg, found := c.messages[tag][key]; found {
+				return msg, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Middleware returns a middleware function that populates the context.Context of each HTTP request with the language
+// negotiated from the languages within the Catalog using its Accept-Language header (see problem.UsingLanguage), unless
+// the context.Context already contains a language. See Catalog.Negotiate for more information.
+//
+// This allows the language to be known when problems are constructed and, as such, is complementary to
+// problem.Generator.Languages, which is used to localize problems again when they are written.
+func (c *Catalog) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if problem.GetLanguage(req.Context()) == "" {
+				w.Header().Add("Vary", "Accept-Language")
+				lang := c.Negotiate(strings.Join(req.Header.Values("Accept-Language"), ","))
+				req = req.WithContext(problem.UsingLanguage(req.Context(), lang))
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// Negotiate returns the language tag of the most suitable language within the Catalog based on the given HTTP
+// Accept-Language header, giving preference to Catalog.Fallback when more than one language is equally acceptable.
+//
+// If none of the languages within the Catalog are acceptable, Catalog.Fallback is returned, or DefaultFallback if
+// Catalog.Fallback is empty.
+func (c *Catalog) Negotiate(acceptLanguage string) string {
+	if lang, acceptable := accept.NegotiateLanguage(acceptLanguage, c.Languages()); acceptable {
+		return lang
+	}
+	return c.fallback()
+}
+
+// Translator returns a problem.Translator that looks up the message mapped to each translation key within the Catalog
+// for the language within the context.Context provided (see problem.GetLanguage), falling back on Catalog.Fallback. See
+// Catalog.Message for more information.
+//
+// Any "{name}" placeholders within the message are filled using the value of the extension with the same name of the
+// problem being localized (see problem.GetTranslationExtensions), while placeholders for any missing extensions are
+// left unchanged. For example;
+//
+//	// "credit.detail": "Your current balance is {balance}"
+//	prob := gen.New(problem.WithDetailKey("credit.detail"), problem.WithExtension("balance", 30))
+//	prob.Detail  // "Your current balance is 30"
+//
+// Translation keys must be either strings or implement fmt.Stringer, otherwise they cannot be resolved.
+func (c *Catalog) Translator() problem.Translator {
+	return func(ctx context.Context, key any) string {
+		var k string
+		switch v := key.(type) {
+		case string:
+			k = v
+		case fmt.Stringer:
+			k = v.String()
+		default:
+			return ""
+		}
+		msg, found := c.Message(problem.GetLanguage(ctx), k)
+		if !found {
+			return ""
+		}
+		return expand(msg, problem.GetTranslationExtensions(ctx))
+	}
+}
+
+// fallback returns Catalog.Fallback if not empty, otherwise DefaultFallback.
+func (c *Catalog) fallback() string {
+	if c.Fallback != "" {
+		return c.Fallback
+	}
+	return DefaultFallback
+}
+
+// expand returns the given message with each "{name}" placeholder replaced by the string representation of the value
+// mapped to name within args, where present. Otherwise, the placeholder is left unchanged.
+func expand(msg string, args map[string]any) string {
+	if len(args) == 0 || !strings.Contains(msg, "{") {
+		return msg
+	}
+	var sb strings.Builder
+	for {
+		start := strings.IndexByte(msg, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(msg[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		sb.WriteString(msg[:start])
+		if v, found := args[msg[start+1:end]]; found {
+			fmt.Fprint(&sb, v)
+		} else {
+			sb.WriteString(msg[start : end+1])
+		}
+		msg = msg[end+1:]
+	}
+	sb.WriteString(msg)
+	return sb.String()
+}
+
+// parentLanguage returns the given lower-cased language tag with its last subtag removed (e.g. "fr" for "fr-ca"), or
+// an empty string if it has no more subtags.
+func parentLanguage(tag string) string {
+	if i := strings.LastIndexByte(tag, '-'); i >= 0 {
+		return tag[:i]
+	}
+	return ""
+}
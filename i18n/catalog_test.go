@@ -0,0 +1,191 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package i18n_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jay-babu/go-problem"
+	problemhttp "github.com/jay-babu/go-problem/http"
+	"github.com/jay-babu/go-problem/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	cat := i18n.Default()
+	assert.Equal(t, []string{"en", "de", "es", "fr"}, cat.Languages())
+
+	for status := 400; status < 600; status++ {
+		def := problemhttp.StatusDefinitionOrElse(status, problem.Definition{})
+		if def.Type.TitleKey == nil {
+			continue
+		}
+		titleKey := def.Type.TitleKey.(string)
+		detailKey := def.DetailKey.(string)
+		for _, lang := range cat.Languages() {
+			for _, key := range []string{titleKey, detailKey} {
+				_, found := cat.Message(lang, key)
+				assert.Truef(t, found, "missing %q message for %q", lang, key)
+			}
+		}
+		title, _ := cat.Message("en", titleKey)
+		assert.Equal(t, def.Type.Title, title, "English title should match that of type")
+	}
+
+	for _, lang := range cat.Languages() {
+		_, found := cat.Message(lang, problem.DefaultRedactedDetailKey)
+		assert.Truef(t, found, "missing %q message for %q", lang, problem.DefaultRedactedDetailKey)
+	}
+}
+
+func TestLoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"messages/en.json":   {Data: []byte(`{"greeting": "Hello", "credit.detail": "Your balance is {balance} {currency}"}`)},
+		"messages/fr.yaml":   {Data: []byte("greeting: Bonjour\ncredit.detail: Votre solde est de {balance} {currency}\n")},
+		"messages/fr-CA.yml": {Data: []byte("greeting: Allô\n")},
+		"README.md":          {Data: []byte("ignored")},
+	}
+	cat, err := i18n.LoadFS(fsys)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en", "fr", "fr-CA"}, cat.Languages())
+
+	testCases := map[string]struct {
+		lang   string
+		key    string
+		expect string
+		found  bool
+	}{
+		"Exact":           {"fr-CA", "greeting", "Allô", true},
+		"CaseInsensitive": {"FR-ca", "greeting", "Allô", true},
+		"Parent":          {"fr-CA", "credit.detail", "Votre solde est de {balance} {currency}", true},
+		"Fallback":        {"de", "greeting", "Hello", true},
+		"Empty":           {"", "greeting", "Hello", true},
+		"Missing":         {"fr", "missing", "", false},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, found := cat.Message(tc.lang, tc.key)
+			assert.Equal(t, tc.expect, actual)
+			assert.Equal(t, tc.found, found)
+		})
+	}
+
+	cat.Fallback = "fr"
+	msg, _ := cat.Message("de", "greeting")
+	assert.Equal(t, "Bonjour", msg)
+	assert.Equal(t, []string{"fr", "en", "fr-CA"}, cat.Languages())
+
+	_, err = i18n.LoadFS(fstest.MapFS{
+		"a/en.json": {Data: []byte(`{}`)},
+		"b/EN.yaml": {Data: []byte(``)},
+		"c/fr.json": {Data: []byte(`{"nested": {"key": "value"}}`)},
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, i18n.ErrCatalog))
+	assert.ErrorContains(t, err, `language "EN" declared in both "a/en.json" and "b/EN.yaml"`)
+	assert.ErrorContains(t, err, "unable to parse c/fr.json")
+}
+
+func TestCatalog_Merge(t *testing.T) {
+	msgs, err := i18n.LoadFS(fstest.MapFS{
+		"fr.json": {Data: []byte(`{"problem.http.NotFound.title": "Ressource introuvable"}`)},
+		"it.json": {Data: []byte(`{"problem.http.NotFound.title": "Non trovato"}`)},
+	})
+	require.NoError(t, err)
+
+	cat := i18n.Default().Merge(msgs)
+	assert.Equal(t, []string{"en", "de", "es", "fr", "it"}, cat.Languages())
+	msg, _ := cat.Message("fr", "problem.http.NotFound.title")
+	assert.Equal(t, "Ressource introuvable", msg)
+	msg, _ = cat.Message("fr", "problem.http.NotFoundDefinition.detail")
+	assert.Equal(t, "La ressource demandée est introuvable", msg)
+	msg, _ = cat.Message("it", "problem.http.Gone.title")
+	assert.Equal(t, "Gone", msg)
+
+	msg, _ = i18n.Default().Message("fr", "problem.http.NotFound.title")
+	assert.Equal(t, "Introuvable", msg, "default catalog should not be modified")
+}
+
+func TestCatalog_Translator(t *testing.T) {
+	msgs, err := i18n.LoadFS(fstest.MapFS{
+		"en.json": {Data: []byte(`{"credit.detail": "Your balance is {balance} {currency}"}`)},
+		"fr.json": {Data: []byte(`{"credit.detail": "Votre solde est de {balance} {currency}"}`)},
+	})
+	require.NoError(t, err)
+	gen := &problem.Generator{Translator: msgs.Translator()}
+
+	prob := gen.New(problem.WithDetailKey("credit.detail"), problem.WithExtension("balance", 30))
+	assert.Equal(t, "Your balance is 30 {currency}", prob.Detail)
+
+	ctx := problem.UsingLanguage(context.Background(), "fr-FR")
+	prob = gen.NewContext(ctx, problem.WithDetailKey("credit.detail"), problem.WithExtension("balance", 30))
+	assert.Equal(t, "Votre solde est de 30 {currency}", prob.Detail)
+
+	translate := msgs.Translator()
+	assert.Empty(t, translate(ctx, "missing"))
+	assert.Empty(t, translate(ctx, 42))
+}
+
+func TestCatalog_Middleware(t *testing.T) {
+	cat := i18n.Default()
+	gen := &problem.Generator{Translator: cat.Translator()}
+	handler := cat.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		prob := problemhttp.NotFoundDefinition.NewContextUsing(req.Context(), gen)
+		_ = gen.WriteProblem(prob, w, req, problem.WriteOptions{LogDisabled: true})
+	}))
+
+	testCases := map[string]struct {
+		acceptLanguage string
+		expectLanguage string
+		expectTitle    string
+		expectDetail   string
+	}{
+		"Default":     {"", "en", "Not Found", "The requested resource could not be found"},
+		"German":      {"de-DE, en;q=0.5", "de", "Nicht gefunden", "Die angeforderte Ressource wurde nicht gefunden"},
+		"Spanish":     {"es", "es", "No encontrado", "No se pudo encontrar el recurso solicitado"},
+		"Unsupported": {"ja", "en", "Not Found", "The requested resource could not be found"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			assert.Equal(t, tc.expectLanguage, rec.Header().Get("Content-Language"))
+			assert.Contains(t, rec.Header().Values("Vary"), "Accept-Language")
+			var prob problem.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &prob))
+			assert.Equal(t, tc.expectTitle, prob.Title)
+			assert.Equal(t, tc.expectDetail, prob.Detail)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrCatalog is returned when a message file cannot be parsed or contains invalid entries.
+var ErrCatalog = errors.New("invalid message catalog")
+
+// LoadFS returns a Catalog containing all messages within the message files found in the given fs.FS.
+//
+// The language of each message file is derived from its name without its extension (e.g. "fr-CA" for "i18n/fr-CA.json")
+// and its format from its extension, where ".json" is parsed as JSON while ".yaml" and ".yml" are parsed as YAML. Each
+// message file must contain a single object mapping translation keys to messages.
+//
+// If any patterns are provided, only files matching at least one of them (see fs.Glob) are loaded. Otherwise, all files
+// within fsys with a ".json", ".yaml", or ".yml" extension are loaded.
+//
+// An error is returned if any file cannot be read or parsed, or if the same language is declared by more than one file.
+// Errors for all invalid files are joined, each wrapping ErrCatalog.
+func LoadFS(fsys fs.FS, patterns ...string) (*Catalog, error) {
+	names, err := findFiles(fsys, patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	c := &Catalog{
+		messages: make(map[string]map[string]string, len(names)),
+		tags:     make(map[string]string, len(names)),
+	}
+	sources := make(map[string]string, len(names))
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		msgs, err := parseFile(name, data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		tag := strings.TrimSuffix(path.Base(name), path.Ext(name))
+		lang := strings.ToLower(tag)
+		if lang == "" {
+			errs = append(errs, fmt.Errorf("%w: language cannot be empty: %s", ErrCatalog, name))
+			continue
+		}
+		if other, dup := sources[lang]; dup {
+			errs = append(errs, fmt.Errorf("%w: language %q declared in both %q and %q", ErrCatalog, tag, other, name))
+			continue
+		}
+		sources[lang] = name
+		c.messages[lang] = msgs
+		c.tags[lang] = tag
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// findFiles returns the names of all message files within the given fs.FS matching any of the patterns provided, or
+// all supported message files if no patterns are provided, in lexical order.
+func findFiles(fsys fs.FS, patterns []string) ([]string, error) {
+	var names []string
+	if len(patterns) > 0 {
+		for _, pattern := range patterns {
+			matches, err := fs.Glob(fsys, pattern)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, matches...)
+		}
+	} else {
+		err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() && isSupportedExt(path.Ext(name)) {
+				names = append(names, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
+
+// isSupportedExt returns whether the given file extension is that of a supported message file format.
+func isSupportedExt(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseFile parses the given data of the message file with the name provided, based on its extension.
+func parseFile(name string, data []byte) (map[string]string, error) {
+	var (
+		err  error
+		msgs map[string]string
+	)
+	switch ext := strings.ToLower(path.Ext(name)); ext {
+	case ".json":
+		err = json.NewDecoder(bytes.NewReader(data)).Decode(&msgs)
+	case ".yaml", ".yml":
+		err = yaml.NewDecoder(bytes.NewReader(data)).Decode(&msgs)
+	default:
+		return nil, fmt.Errorf("%w: unsupported file extension %q: %s", ErrCatalog, ext, name)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: unable to parse %s: %w", ErrCatalog, name, err)
+	}
+	if msgs == nil {
+		msgs = make(map[string]string)
+	}
+	return msgs, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTranslations = map[string]map[any]string{
+	"en": {"test.title": "Out of credit", "test.detail": "Your balance is too low"},
+	"fr": {"test.title": "Crédit insuffisant", "test.detail": "Votre solde est trop bas"},
+}
+
+func testTranslator(ctx context.Context, key any) string {
+	lang := problem.GetLanguage(ctx)
+	if lang == "" {
+		lang = "en"
+	}
+	return testTranslations[lang][key]
+}
+
+func TestGenerator_Localize(t *testing.T) {
+	gen := &problem.Generator{Translator: testTranslator}
+	def := problem.Definition{
+		DetailKey: "test.detail",
+		Type:      problem.Type{Status: http.StatusForbidden, TitleKey: "test.title"},
+	}
+
+	prob := def.NewUsing(gen)
+	assert.Equal(t, "Out of credit", prob.Title)
+	assert.Equal(t, "Your balance is too low", prob.Detail)
+
+	localized := gen.Localize(problem.UsingLanguage(context.Background(), "fr"), prob)
+	assert.Equal(t, "Crédit insuffisant", localized.Title)
+	assert.Equal(t, "Votre solde est trop bas", localized.Detail)
+	assert.Equal(t, "Out of credit", prob.Title, "original should not be modified")
+
+	wrapped := gen.New(problem.Wrap(prob), problem.WithDetail("Top up your account"))
+	wrapped = gen.Localize(problem.UsingLanguage(context.Background(), "fr"), wrapped)
+	assert.Equal(t, "Top up your account", wrapped.Detail, "explicit detail should not be localized")
+
+	unknown := gen.Localize(problem.UsingLanguage(context.Background(), "es"), prob)
+	assert.Equal(t, "Out of credit", unknown.Title, "title should fall back on constructed value")
+
+	var decoded problem.Problem
+	b, err := json.Marshal(prob)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Same(t, &decoded, gen.Localize(problem.UsingLanguage(context.Background(), "fr"), &decoded))
+}
+
+func TestGenerator_Localize_FieldErrors(t *testing.T) {
+	gen := &problem.Generator{Translator: testTranslator}
+	var errs problem.ValidationErrors
+	errs.Pointer("/age", "must be a positive integer").
+		Add(problem.FieldError{Detail: "fallback", DetailKey: "test.detail", Pointer: "/balance"})
+
+	prob := gen.New(problem.WithValidationErrors(&errs))
+	fieldErrs := prob.FieldErrors()
+	require.Len(t, fieldErrs, 2)
+	assert.Equal(t, "Your balance is too low", fieldErrs[1].Detail)
+	assert.Equal(t, "test.detail", fieldErrs[1].DetailKey)
+
+	localized := gen.Localize(problem.UsingLanguage(context.Background(), "fr"), prob)
+	fieldErrs = localized.FieldErrors()
+	require.Len(t, fieldErrs, 2)
+	assert.Equal(t, "must be a positive integer", fieldErrs[0].Detail)
+	assert.Equal(t, "Votre solde est trop bas", fieldErrs[1].Detail)
+	assert.Equal(t, "Your balance is too low", prob.FieldErrors()[1].Detail, "original should not be modified")
+}
+
+func TestGenerator_Localize_LogInfo(t *testing.T) {
+	gen := &problem.Generator{Translator: testTranslator}
+	prob := gen.New(problem.WithDetail("fallback", problem.FlagLog), problem.WithDetailKey("test.detail"))
+	assert.Empty(t, prob.Detail)
+	assert.Equal(t, "Your balance is too low", prob.LogInfo().Detail)
+
+	localized := gen.Localize(problem.UsingLanguage(context.Background(), "fr"), prob)
+	assert.Empty(t, localized.Detail)
+	assert.Equal(t, "Votre solde est trop bas", localized.LogInfo().Detail)
+	assert.Equal(t, "Your balance is too low", prob.LogInfo().Detail, "original should not be modified")
+}
+
+func TestWriteProblem_Language(t *testing.T) {
+	var logged *problem.Problem
+	gen := &problem.Generator{
+		Languages: []string{"en", "fr"},
+		Logger: func(_ context.Context, _ problem.LogLevel, _ string, args ...any) {
+			logged = args[len(args)-1].(*problem.Problem)
+		},
+		Translator: testTranslator,
+	}
+	prob := gen.New(problem.WithTitleKey("test.title"), problem.WithDetailKey("test.detail"))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "fr-CA, en;q=0.5")
+	require.NoError(t, gen.WriteProblem(prob, rec, req))
+
+	assert.Equal(t, "fr", rec.Header().Get("Content-Language"))
+	assert.Contains(t, rec.Header().Values("Vary"), "Accept-Language")
+	var actual problem.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+	assert.Equal(t, "Crédit insuffisant", actual.Title)
+	assert.Equal(t, "Votre solde est trop bas", actual.Detail)
+	require.NotNil(t, logged)
+	assert.Equal(t, "Out of credit", logged.Title, "logs should use the default language")
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "es")
+	require.NoError(t, gen.WriteProblem(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+	assert.Equal(t, "en", rec.Header().Get("Content-Language"))
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "en")
+	req = req.WithContext(problem.UsingLanguage(req.Context(), "fr"))
+	require.NoError(t, gen.WriteProblem(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+	assert.Equal(t, "fr", rec.Header().Get("Content-Language"))
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "fr")
+	require.NoError(t, problem.WriteProblem(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+	assert.Empty(t, rec.Header().Get("Content-Language"))
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "fr")
+	untranslated := gen.New(problem.WithTitle("Out of credit"), problem.WithDetail("Your balance is too low"))
+	require.NoError(t, gen.WriteProblem(untranslated, rec, req, problem.WriteOptions{LogDisabled: true}))
+	assert.Empty(t, rec.Header().Get("Content-Language"), "language should only be set when localized")
+	assert.Contains(t, rec.Header().Values("Vary"), "Accept-Language")
+}
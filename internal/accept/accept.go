@@ -0,0 +1,244 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package accept provides support for parsing HTTP Accept and Accept-Language headers and negotiating the most suitable
+// offer against them.
+package accept
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LanguageRange is a single language range parsed from an HTTP Accept-Language header.
+type LanguageRange struct {
+	// Tag is the lower-cased language tag of the language range (e.g. "en-us" or "*").
+	Tag string
+	// Q is the quality value (i.e. weight) of the language range, between zero and one (inclusive).
+	Q float64
+}
+
+// Matches returns whether the LanguageRange matches the given language tag, which is expected to be lower-cased, in
+// accordance with the basic filtering scheme of RFC 4647 (i.e. "en" matches both "en" and "en-us").
+func (lr LanguageRange) Matches(tag string) bool {
+	return lr.Tag == "*" || lr.Tag == tag || strings.HasPrefix(tag, lr.Tag+"-")
+}
+
+// Specificity returns the specificity of the LanguageRange, where a higher value indicates a more specific language
+// range.
+func (lr LanguageRange) Specificity() int {
+	if lr.Tag == "*" {
+		return 0
+	}
+	return strings.Count(lr.Tag, "-") + 1
+}
+
+// MediaRange is a single media range parsed from an HTTP Accept header.
+type MediaRange struct {
+	// Type is the lower-cased type of the media range (e.g. "application" or "*").
+	Type string
+	// Subtype is the lower-cased subtype of the media range (e.g. "problem+json" or "*").
+	Subtype string
+	// Q is the quality value (i.e. weight) of the media range, between zero and one (inclusive).
+	Q float64
+}
+
+// Matches returns whether the MediaRange matches the given media type, which is expected to be lower-cased and contain
+// no parameters.
+func (mr MediaRange) Matches(mediaType string) bool {
+	t, st, ok := strings.Cut(mediaType, "/")
+	if !ok {
+		return false
+	}
+	return (mr.Type == "*" || mr.Type == t) && (mr.Subtype == "*" || mr.Subtype == st)
+}
+
+// Specificity returns the specificity of the MediaRange, where a higher value indicates a more specific media range.
+func (mr MediaRange) Specificity() int {
+	switch {
+	case mr.Type == "*":
+		return 0
+	case mr.Subtype == "*":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ParseLanguageRanges parses all valid language ranges within the given HTTP Accept-Language header, in the order they
+// were declared.
+//
+// Any malformed language range or quality value is ignored.
+func ParseLanguageRanges(header string) []LanguageRange {
+	var ranges []LanguageRange
+	for _, elem := range strings.Split(header, ",") {
+		params := strings.Split(elem, ";")
+		tag := strings.ToLower(strings.TrimSpace(params[0]))
+		if tag == "" || strings.HasPrefix(tag, "-") || strings.HasSuffix(tag, "-") || strings.Contains(tag, "--") {
+			continue
+		}
+		q, ok := parseQ(params[1:])
+		if !ok {
+			continue
+		}
+		ranges = append(ranges, LanguageRange{Tag: tag, Q: q})
+	}
+	return ranges
+}
+
+// ParseMediaRanges parses all valid media ranges within the given HTTP Accept header, in the order they were declared.
+//
+// Any malformed media range or quality value is ignored.
+func ParseMediaRanges(header string) []MediaRange {
+	var ranges []MediaRange
+	for _, elem := range strings.Split(header, ",") {
+		params := strings.Split(elem, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		t, st, ok := strings.Cut(mediaType, "/")
+		if !ok || t == "" || st == "" || (t == "*" && st != "*") {
+			continue
+		}
+		q, ok := parseQ(params[1:])
+		if !ok {
+			continue
+		}
+		ranges = append(ranges, MediaRange{Type: t, Subtype: st, Q: q})
+	}
+	return ranges
+}
+
+// NegotiateMediaType returns the most suitable media type from offers based on the given HTTP Accept header.
+//
+// Offers are expected to be lower-cased media types without parameters and must be provided in order of preference as
+// the earliest offer is returned when several are equally acceptable. If header is empty or contains no valid media
+// ranges, the first offer is returned as any media type is acceptable.
+//
+// false is returned only if none of the offers are acceptable.
+func NegotiateMediaType(header string, offers []string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	ranges := ParseMediaRanges(header)
+	if len(ranges) == 0 {
+		return offers[0], true
+	}
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, offer := range offers {
+		if q := qualityOf(ranges, offer); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// NegotiateLanguage returns the most suitable language tag from offers based on the given HTTP Accept-Language header.
+//
+// Offers must be provided in order of preference as the earliest offer is returned when several are equally acceptable,
+// and the matching offer is returned as provided (i.e. without being lower-cased). If header is empty or contains no
+// valid language ranges, the first offer is returned as any language is acceptable.
+//
+// In addition to the basic filtering scheme of RFC 4647, where a language range matches any language tag that it is a
+// prefix of, an offer is also considered to match a more specific language range that it is a prefix of (e.g. offer
+// "en" matches language range "en-US"), similar to the lookup scheme of RFC 4647. However, when several offers are
+// equally acceptable, those matched in accordance with the basic filtering scheme are preferred.
+//
+// false is returned only if none of the offers are acceptable.
+func NegotiateLanguage(header string, offers []string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	ranges := ParseLanguageRanges(header)
+	if len(ranges) == 0 {
+		return offers[0], true
+	}
+	var (
+		best       string
+		bestQ      float64
+		bestFilter bool
+	)
+	for _, offer := range offers {
+		if q, filter := languageQualityOf(ranges, strings.ToLower(offer)); q > bestQ || (q > 0 && q == bestQ && filter && !bestFilter) {
+			best, bestQ, bestFilter = offer, q, filter
+		}
+	}
+	return best, bestQ > 0
+}
+
+// languageQualityOf returns the quality value of the most specific language range within ranges that matches the given
+// language tag, along with true. If no language range matches, the quality value of the first language range that the
+// language tag is a prefix of is returned instead, along with false, or zero if there is none. See NegotiateLanguage
+// for more information.
+func languageQualityOf(ranges []LanguageRange, tag string) (float64, bool) {
+	var (
+		q           float64
+		specificity = -1
+	)
+	for _, lr := range ranges {
+		if s := lr.Specificity(); s > specificity && lr.Matches(tag) {
+			q, specificity = lr.Q, s
+		}
+	}
+	if specificity >= 0 {
+		return q, true
+	}
+	for _, lr := range ranges {
+		if strings.HasPrefix(lr.Tag, tag+"-") {
+			return lr.Q, false
+		}
+	}
+	return 0, false
+}
+
+// parseQ returns the quality value found within the given media range parameters, defaulting to one if none is
+// present.
+//
+// false is returned if the quality value is malformed.
+func parseQ(params []string) (float64, bool) {
+	for _, param := range params {
+		k, v, _ := strings.Cut(param, "=")
+		if !strings.EqualFold(strings.TrimSpace(k), "q") {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || q < 0 || q > 1 {
+			return 0, false
+		}
+		return q, true
+	}
+	return 1, true
+}
+
+// qualityOf returns the quality value of the most specific media range within ranges that matches the given media
+// type, or zero if none match.
+func qualityOf(ranges []MediaRange, mediaType string) float64 {
+	var (
+		q           float64
+		specificity = -1
+	)
+	for _, mr := range ranges {
+		if s := mr.Specificity(); s > specificity && mr.Matches(mediaType) {
+			q, specificity = mr.Q, s
+		}
+	}
+	return q
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package accept
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NegotiateLanguage(t *testing.T) {
+	offers := []string{"en", "fr", "de-CH"}
+	testCases := map[string]struct {
+		header     string
+		expect     string
+		acceptable bool
+	}{
+		"Empty":            {"", "en", true},
+		"Malformed":        {"-", "en", true},
+		"Any":              {"*", "en", true},
+		"Exact":            {"fr", "fr", true},
+		"CaseInsensitive":  {"DE-ch", "de-CH", true},
+		"Prefix":           {"de", "de-CH", true},
+		"MoreSpecific":     {"fr-CA", "fr", true},
+		"Weighted":         {"fr;q=0.5, de-CH;q=0.9", "de-CH", true},
+		"WeightTie":        {"fr, en", "en", true},
+		"MoreSpecificQ":    {"en-GB, fr;q=0.8", "en", true},
+		"FilterPreferred":  {"en-GB, fr", "fr", true},
+		"SpecificExcludes": {"*, en;q=0", "fr", true},
+		"Unacceptable":     {"es", "", false},
+		"AllExcluded":      {"*;q=0", "", false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, acceptable := NegotiateLanguage(tc.header, offers)
+			assert.Equal(t, tc.expect, actual, "unexpected language")
+			assert.Equal(t, tc.acceptable, acceptable, "unexpected acceptability")
+		})
+	}
+}
+
+func Test_NegotiateMediaType(t *testing.T) {
+	offers := []string{"application/problem+json", "application/problem+xml", "application/json", "application/xml"}
+	testCases := map[string]struct {
+		header     string
+		expect     string
+		acceptable bool
+	}{
+		"Empty":               {"", "application/problem+json", true},
+		"Malformed":           {"nonsense", "application/problem+json", true},
+		"Any":                 {"*/*", "application/problem+json", true},
+		"Exact":               {"application/problem+xml", "application/problem+xml", true},
+		"Generic":             {"application/xml", "application/xml", true},
+		"CaseInsensitive":     {"Application/XML", "application/xml", true},
+		"TypeWildcard":        {"application/*", "application/problem+json", true},
+		"Weighted":            {"application/json;q=0.5, application/xml;q=0.9", "application/xml", true},
+		"WeightTie":           {"application/xml, application/json", "application/json", true},
+		"SpecificOverrides":   {"application/*;q=0.1, application/xml", "application/xml", true},
+		"SpecificExcludes":    {"*/*, application/problem+json;q=0", "application/problem+xml", true},
+		"Params":              {"application/xml; charset=utf-8; q=0.8", "application/xml", true},
+		"InvalidQIgnored":     {"application/json;q=2, application/xml", "application/xml", true},
+		"Unacceptable":        {"text/plain", "", false},
+		"AllExcluded":         {"*/*;q=0", "", false},
+		"MalformedRangeMixed": {"*/json, text/html", "", false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual, acceptable := NegotiateMediaType(tc.header, offers)
+			assert.Equal(t, tc.expect, actual, "unexpected media type")
+			assert.Equal(t, tc.acceptable, acceptable, "unexpected acceptability")
+		})
+	}
+}
+
+func Test_ParseLanguageRanges(t *testing.T) {
+	ranges := ParseLanguageRanges("fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5, -bad, de;q=abc")
+	assert.Equal(t, []LanguageRange{
+		{Tag: "fr-ch", Q: 1},
+		{Tag: "fr", Q: 0.9},
+		{Tag: "en", Q: 0.8},
+		{Tag: "*", Q: 0.5},
+	}, ranges)
+}
+
+func Test_ParseMediaRanges(t *testing.T) {
+	ranges := ParseMediaRanges("text/html, application/xhtml+xml;q=0.9, */*;q=0.8, bad, x/y;q=abc")
+	assert.Equal(t, []MediaRange{
+		{Type: "text", Subtype: "html", Q: 1},
+		{Type: "application", Subtype: "xhtml+xml", Q: 0.9},
+		{Type: "*", Subtype: "*", Q: 0.8},
+	}, ranges)
+}
@@ -0,0 +1,392 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	// jsonHex contains the hexadecimal digits used when escaping characters within JSON strings.
+	jsonHex = "0123456789abcdef"
+	// jsonReservedMemberCount is the maximum number of reserved members that can be present within a Problem when
+	// marshaled into JSON.
+	jsonReservedMemberCount = 8
+)
+
+// appendJSONProblem appends the JSON encoding of the given Problem to dst in a single pass, returning the extended
+// buffer.
+//
+// All members, including those within Problem.Extensions, are written in order of their names which is consistent with
+// how encoding/json marshals maps. Reserved members that are empty are omitted, where permitted.
+//
+// An error is returned if unable to marshal any extension or Problem.Extensions contains a key that is either empty or
+// reserved (i.e. conflicts with Problem-level fields).
+func appendJSONProblem(dst []byte, p *Problem) ([]byte, error) {
+	names := make([]string, 0, jsonReservedMemberCount+len(p.Extensions))
+	if p.Code != "" {
+		names = append(names, "code")
+	}
+	if p.Detail != "" {
+		names = append(names, "detail")
+	}
+	if p.Instance != "" {
+		names = append(names, "instance")
+	}
+	if p.Stack != "" {
+		names = append(names, "stack")
+	}
+	names = append(names, "status", "title", "type")
+	if p.UUID != "" {
+		names = append(names, "uuid")
+	}
+	if len(p.Extensions) > 0 {
+		for k := range p.Extensions {
+			if err := validationExtensionKey(k); err != nil {
+				return nil, err
+			}
+		}
+		names = slices.AppendSeq(names, maps.Keys(p.Extensions))
+		slices.Sort(names)
+	}
+
+	dst = append(dst, '{')
+	for i, name := range names {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = appendJSONString(dst, name)
+		dst = append(dst, ':')
+		switch name {
+		case "code":
+			dst = appendJSONString(dst, string(p.Code))
+		case "detail":
+			dst = appendJSONString(dst, p.Detail)
+		case "instance":
+			dst = appendJSONString(dst, p.Instance)
+		case "stack":
+			dst = appendJSONString(dst, p.Stack)
+		case "status":
+			dst = strconv.AppendInt(dst, int64(p.Status), 10)
+		case "title":
+			dst = appendJSONString(dst, p.Title)
+		case "type":
+			dst = appendJSONString(dst, p.Type)
+		case "uuid":
+			dst = appendJSONString(dst, p.UUID)
+		default:
+			b, err := json.Marshal(p.Extensions[name])
+			if err != nil {
+				return nil, err
+			}
+			dst = append(dst, b...)
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+// decodeJSONProblem decodes the given JSON data into the Problem provided in a single pass.
+//
+// The members of the JSON object are scanned once, with reserved members being decoded directly into their
+// corresponding fields, matching names exactly, while only the values of all other members are decoded generically and
+// retained within Problem.Extensions. Decoding JSON null leaves the Problem zeroed.
+//
+// An error is returned if data is not valid JSON, does not contain a JSON object, or any reserved member contains a
+// value of an unexpected type.
+func decodeJSONProblem(data []byte, p *Problem) error {
+	if !json.Valid(data) {
+		// Delegate to encoding/json so that a consistent *json.SyntaxError is returned
+		var v any
+		return json.Unmarshal(data, &v)
+	}
+
+	i := skipJSONSpace(data, 0)
+	switch data[i] {
+	case '{':
+	case 'n':
+		*p = Problem{}
+		return nil
+	default:
+		return &json.UnmarshalTypeError{
+			Value:  jsonValueKind(data[i]),
+			Type:   reflect.TypeFor[Problem](),
+			Offset: int64(i + 1),
+		}
+	}
+
+	var jp Problem
+	for i = skipJSONSpace(data, i+1); data[i] != '}'; i = skipJSONSpace(data, i+1) {
+		if data[i] == ',' {
+			i = skipJSONSpace(data, i+1)
+		}
+		end := skipJSONValue(data, i)
+		key := data[i:end]
+		i = skipJSONSpace(data, skipJSONSpace(data, end)+1)
+		end = skipJSONValue(data, i)
+		value := data[i:end]
+		i = skipJSONSpace(data, end) - 1
+
+		name, err := jsonMemberName(key)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "code":
+			var code string
+			code, err = jsonStringMember(name, value, end)
+			jp.Code = Code(code)
+		case "detail":
+			jp.Detail, err = jsonStringMember(name, value, end)
+		case "instance":
+			jp.Instance, err = jsonStringMember(name, value, end)
+		case "stack":
+			jp.Stack, err = jsonStringMember(name, value, end)
+		case "status":
+			jp.Status, err = jsonIntMember(name, value, end)
+		case "title":
+			jp.Title, err = jsonStringMember(name, value, end)
+		case "type":
+			jp.Type, err = jsonStringMember(name, value, end)
+		case "uuid":
+			jp.UUID, err = jsonStringMember(name, value, end)
+		default:
+			var v any
+			if err = json.Unmarshal(value, &v); err == nil {
+				if jp.Extensions == nil {
+					jp.Extensions = make(Extensions)
+				}
+				jp.Extensions[name] = v
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	*p = jp
+	return nil
+}
+
+// jsonMemberName returns the name of a JSON object member from the given JSON string, which is expected to be valid.
+//
+// The string is only decoded using encoding/json if it contains any escape sequences or invalid UTF-8.
+func jsonMemberName(data []byte) (string, error) {
+	if s, ok := jsonSimpleString(data); ok {
+		return s, nil
+	}
+	var s string
+	err := json.Unmarshal(data, &s)
+	return s, err
+}
+
+// jsonIntMember returns the given JSON value, which is expected to be valid, of the reserved member with the given name
+// as an int. offset is the offset within the input immediately after the value and is only used for errors.
+//
+// An error is returned if the value is neither null nor a number that can be represented as an int.
+func jsonIntMember(name string, data []byte, offset int) (int, error) {
+	switch data[0] {
+	case 'n':
+		return 0, nil
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		if i, err := strconv.Atoi(string(data)); err == nil {
+			return i, nil
+		}
+		if f, err := strconv.ParseFloat(string(data), 64); err == nil {
+			if i := int(f); float64(i) == f {
+				return i, nil
+			}
+		}
+		return 0, &json.UnmarshalTypeError{
+			Value:  "number " + string(data),
+			Type:   reflect.TypeFor[int](),
+			Offset: int64(offset),
+			Field:  name,
+		}
+	default:
+		return 0, &json.UnmarshalTypeError{
+			Value:  jsonValueKind(data[0]),
+			Type:   reflect.TypeFor[int](),
+			Offset: int64(offset),
+			Field:  name,
+		}
+	}
+}
+
+// jsonStringMember returns the given JSON value, which is expected to be valid, of the reserved member with the given
+// name as a string. offset is the offset within the input immediately after the value and is only used for errors.
+//
+// An error is returned if the value is neither null nor a string.
+func jsonStringMember(name string, data []byte, offset int) (string, error) {
+	switch data[0] {
+	case 'n':
+		return "", nil
+	case '"':
+		return jsonMemberName(data)
+	default:
+		return "", &json.UnmarshalTypeError{
+			Value:  jsonValueKind(data[0]),
+			Type:   reflect.TypeFor[string](),
+			Offset: int64(offset),
+			Field:  name,
+		}
+	}
+}
+
+// jsonSimpleString returns the contents of the given JSON string, which is expected to be valid, provided that it
+// contains neither escape sequences nor invalid UTF-8, and so can be used as is.
+func jsonSimpleString(data []byte) (string, bool) {
+	contents := data[1 : len(data)-1]
+	for _, b := range contents {
+		if b == '\\' {
+			return "", false
+		}
+	}
+	if !utf8.Valid(contents) {
+		return "", false
+	}
+	return string(contents), true
+}
+
+// jsonValueKind returns a description of the kind of JSON value starting with the given byte.
+func jsonValueKind(b byte) string {
+	switch b {
+	case '[':
+		return "array"
+	case '{':
+		return "object"
+	case 't', 'f':
+		return "bool"
+	case '"':
+		return "string"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// skipJSONSpace returns the index of the first byte within data, starting from i, that is not insignificant whitespace.
+func skipJSONSpace(data []byte, i int) int {
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// skipJSONValue returns the index immediately after the JSON value starting at index i within data, which is expected
+// to be valid JSON.
+func skipJSONValue(data []byte, i int) int {
+	switch data[i] {
+	case '"':
+		for i++; data[i] != '"'; i++ {
+			if data[i] == '\\' {
+				i++
+			}
+		}
+		return i + 1
+	case '{', '[':
+		depth := 0
+		for ; i < len(data); i++ {
+			switch data[i] {
+			case '"':
+				i = skipJSONValue(data, i) - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				if depth--; depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return i
+	default:
+		for ; i < len(data); i++ {
+			switch data[i] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return i
+			}
+		}
+		return i
+	}
+}
+
+// appendJSONString appends the JSON encoding of the given string to dst, returning the extended buffer.
+//
+// The encoding is consistent with that of encoding/json, including the escaping of HTML characters, U+2028, and U+2029
+// as well as the replacement of invalid UTF-8 with U+FFFD.
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '\\', '"':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', jsonHex[b>>4], jsonHex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', jsonHex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
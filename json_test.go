@@ -0,0 +1,229 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyProblem is used to marshal/unmarshal a problem.Problem without its own json.Marshaler and json.Unmarshaler
+// implementations being invoked.
+type legacyProblem problem.Problem
+
+// legacyMarshalJSON marshals the problem.Problem provided into JSON using the original double-pass implementation, for
+// comparison purposes.
+func legacyMarshalJSON(p *problem.Problem) ([]byte, error) {
+	b, err := json.Marshal(legacyProblem(*p))
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Extensions) == 0 {
+		return b, nil
+	}
+	var m map[string]any
+	if err = json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	return json.Marshal(m)
+}
+
+// legacyUnmarshalJSON unmarshals the JSON data provided into the problem.Problem using the original double-pass
+// implementation, for comparison purposes.
+func legacyUnmarshalJSON(data []byte, p *problem.Problem) error {
+	var lp legacyProblem
+	if err := json.Unmarshal(data, &lp); err != nil {
+		return err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	for _, k := range []string{"code", "detail", "instance", "stack", "status", "title", "type", "uuid"} {
+		delete(m, k)
+	}
+	if len(m) > 0 {
+		lp.Extensions = m
+	}
+	*p = problem.Problem(lp)
+	return nil
+}
+
+func newJSONTestProblem() *problem.Problem {
+	return &problem.Problem{
+		Code:   "FOO-400",
+		Detail: "Your current balance is 30, but that costs 50. <script>& ",
+		Extensions: problem.Extensions{
+			"accounts": []string{"/account/12345", "/account/67890"},
+			"balance":  30,
+			"errors": []map[string]any{
+				{"detail": "must be a positive integer", "pointer": "#/age"},
+				{"detail": "must be 'green', 'red' or 'blue'", "pointer": "#/profile/color"},
+			},
+			"zzz": nil,
+		},
+		Instance: "/account/12345/msgs/abc",
+		Status:   403,
+		Title:    "You do not have enough credit.",
+		Type:     "https://example.com/probs/out-of-credit",
+		UUID:     "c5f2a3a1-92c9-4a59-a2f8-8ba1f8a4f9a4",
+	}
+}
+
+func TestProblem_MarshalJSON(t *testing.T) {
+	testCases := map[string]*problem.Problem{
+		"empty":      {},
+		"extensions": newJSONTestProblem(),
+		"no extensions": {
+			Detail: "invalid\xffutf8\t\"quoted\"\\",
+			Status: 400,
+			Title:  "Bad Request",
+			Type:   "about:blank",
+		},
+	}
+	for name, p := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expected, err := legacyMarshalJSON(p)
+			require.NoError(t, err)
+			actual, err := json.Marshal(p)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+
+	t.Run("reserved extension", func(t *testing.T) {
+		_, err := json.Marshal(&problem.Problem{Extensions: problem.Extensions{"status": 500}})
+		assert.Error(t, err)
+	})
+	t.Run("empty extension", func(t *testing.T) {
+		_, err := json.Marshal(&problem.Problem{Extensions: problem.Extensions{"": 500}})
+		assert.Error(t, err)
+	})
+}
+
+func TestProblem_UnmarshalJSON(t *testing.T) {
+	data, err := json.Marshal(newJSONTestProblem())
+	require.NoError(t, err)
+
+	var expected, actual problem.Problem
+	require.NoError(t, legacyUnmarshalJSON(data, &expected))
+	require.NoError(t, json.Unmarshal(data, &actual))
+	assert.Equal(t, expected, actual)
+
+	assert.Error(t, json.Unmarshal([]byte(`[]`), &actual))
+	assert.Error(t, json.Unmarshal([]byte(`{"status":"403"}`), &actual))
+}
+
+func TestProblem_UnmarshalJSON_Scanning(t *testing.T) {
+	testCases := map[string]string{
+		"empty":          `{}`,
+		"whitespace":     " {\n\t\"status\" : 404 ,\r\n\"title\":\"Not Found\" } ",
+		"null":           `null`,
+		"null members":   `{"detail":null,"status":null,"zzz":null}`,
+		"escaped names":  `{"st\u0061tus":409,"ti\u0074le":"Conflict","a\"b":1}`,
+		"escaped values": `{"detail":"line\nbreak \"quoted\" \u00e9\ud83d\ude00","type":"a\/b"}`,
+		"invalid utf8":   "{\"detail\":\"bad\xff\",\"ext\xfe\":true}",
+		"nested": `{"errors":[{"pointer":"/a","detail":"x}]\\\""},[1,{"b":[]}]],"obj":{"k":"{["},` +
+			`"num":-1.5e3,"bool":false,"status":400}`,
+		"negative status": `{"status":-1}`,
+	}
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var expected, actual problem.Problem
+			require.NoError(t, legacyUnmarshalJSON([]byte(data), &expected))
+			require.NoError(t, json.Unmarshal([]byte(data), &actual))
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("integral float status", func(t *testing.T) {
+		var actual problem.Problem
+		require.NoError(t, json.Unmarshal([]byte(`{"status":403.0}`), &actual))
+		assert.Equal(t, 403, actual.Status)
+	})
+
+	errorCases := map[string]string{
+		"syntax":            `{"status":`,
+		"trailing":          `{} {}`,
+		"string":            `"problem"`,
+		"fractional status": `{"status":403.5}`,
+		"bool title":        `{"title":true}`,
+		"object type":       `{"type":{}}`,
+	}
+	for name, data := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			var actual problem.Problem
+			assert.Error(t, json.Unmarshal([]byte(data), &actual))
+		})
+	}
+}
+
+func BenchmarkProblem_MarshalJSON(b *testing.B) {
+	p := newJSONTestProblem()
+	b.Run("legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := legacyMarshalJSON(p); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("single-pass", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := p.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkProblem_UnmarshalJSON(b *testing.B) {
+	data, err := json.Marshal(newJSONTestProblem())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var p problem.Problem
+			if err := legacyUnmarshalJSON(data, &p); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("single-pass", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var p problem.Problem
+			if err := p.UnmarshalJSON(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
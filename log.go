@@ -22,12 +22,25 @@ package problem
 
 import (
 	"context"
+	"encoding"
+	"fmt"
 	"log/slog"
+	"maps"
+	"strconv"
+	"strings"
 )
 
 type (
 	// LogInfo contains information associated with a Problem that is only relevant for logging purposes.
 	LogInfo struct {
+		// Detail is the detail visible when the Problem is logged, which may not be present on Problem.Detail.
+		//
+		// Detail is only populated if either Builder.Detail or WithDetail were used and either passed no flags or FlagLog
+		// explicitly, or if neither were used.
+		Detail string
+		// Extensions contains the extensions visible when the Problem is logged, which include those within
+		// Problem.Extensions along with any extensions that are only visible in logs (see Builder.ExtensionLogOnly).
+		Extensions map[string]any
 		// Level is the LogLevel that has either been explicitly defined during construction or inherited from a Type or
 		// another Problem within an error's tree if unwrapped accordingly.
 		Level LogLevel
@@ -43,6 +56,8 @@ type (
 		// UUID is only populated if Generator.UUIDFlag has FlagLog or either Builder.UUID or WithUUID were used and
 		// either passed no flags or FlagLog explicitly.
 		UUID string
+		// detailHidden is whether Problem.Detail is hidden from logs (i.e. FlagLog was not passed).
+		detailHidden bool
 	}
 
 	// LogLeveler is a function that can be used by a Generator to override the LogLevel derived from a Type (i.e.
@@ -131,6 +146,20 @@ func (p *Problem) LogInfo() LogInfo {
 	var info LogInfo
 	if p != nil {
 		info = p.logInfo
+		// Internally, Detail and Extensions only contain data that is hidden from the fields of the Problem
+		switch {
+		case info.detailHidden:
+			info.Detail = ""
+		case p.Detail != "":
+			info.Detail = p.Detail
+		}
+		if len(info.Extensions) == 0 {
+			info.Extensions = p.Extensions
+		} else if len(p.Extensions) > 0 {
+			extensions := maps.Clone(p.Extensions)
+			maps.Copy(extensions, info.Extensions)
+			info.Extensions = extensions
+		}
 	}
 	if info.Level == 0 {
 		info.Level = DefaultLogLevel
@@ -139,19 +168,35 @@ func (p *Problem) LogInfo() LogInfo {
 }
 
 // LogValue returns a slog.GroupValue representation of the Problem containing attrs for only non-empty fields.
+//
+// The detail and extensions are those visible in logs, which may include data that is never serialized. See
+// Problem.LogInfo for more information.
+//
+// A single cause (see Problem.Causes) is logged as "error", while multiple causes are logged within an "errors" group,
+// keyed by their index.
 func (p *Problem) LogValue() slog.Value {
+	info := p.LogInfo()
 	attrs := make([]slog.Attr, 0, 10)
 	if p.Code != "" {
 		attrs = append(attrs, slog.String("code", string(p.Code)))
 	}
-	if p.Detail != "" {
-		attrs = append(attrs, slog.String("detail", p.Detail))
+	if info.Detail != "" {
+		attrs = append(attrs, slog.String("detail", info.Detail))
 	}
-	if p.err != nil {
-		attrs = append(attrs, slog.Any("error", p.err))
+	switch causes := p.Causes(); len(causes) {
+	case 0:
+	case 1:
+		attrs = append(attrs, slog.Any("error", causes[0]))
+	default:
+		// Each cause is logged in the same way as a single cause, keyed by its index
+		errs := make([]any, len(causes))
+		for i, cause := range causes {
+			errs[i] = slog.Any(strconv.Itoa(i), cause)
+		}
+		attrs = append(attrs, slog.Group("errors", errs...))
 	}
-	if len(p.Extensions) > 0 {
-		attrs = append(attrs, mapLogGroup("extensions", p.Extensions))
+	if len(info.Extensions) > 0 {
+		attrs = append(attrs, mapLogGroup("extensions", info.Extensions))
 	}
 	if p.Instance != "" {
 		attrs = append(attrs, slog.String("instance", p.Instance))
@@ -242,6 +287,65 @@ const (
 	LogLevelError
 )
 
+var (
+	_ encoding.TextMarshaler   = LogLevel(0)
+	_ encoding.TextUnmarshaler = (*LogLevel)(nil)
+	_ fmt.Stringer             = LogLevel(0)
+)
+
+// MarshalText marshals the LogLevel into text. See LogLevel.String for more information.
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// String returns the name of the LogLevel (e.g. "DEBUG") or an empty string if it is the zero value. Any other
+// unrecognized LogLevel is represented by its numeric value.
+func (l LogLevel) String() string {
+	switch l {
+	case 0:
+		return ""
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	default:
+		return strconv.FormatUint(uint64(l), 10)
+	}
+}
+
+// UnmarshalText unmarshals the text provided into the LogLevel.
+//
+// The text is expected to be the name of a LogLevel, ignoring case (e.g. "debug" or "DEBUG"), its numeric value, or
+// empty (i.e. the zero value).
+//
+// An error is returned if text cannot be unmarshaled into a LogLevel.
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	s := string(text)
+	switch strings.ToUpper(s) {
+	case "":
+		*l = 0
+	case "DEBUG":
+		*l = LogLevelDebug
+	case "INFO":
+		*l = LogLevelInfo
+	case "WARN", "WARNING":
+		*l = LogLevelWarn
+	case "ERROR":
+		*l = LogLevelError
+	default:
+		v, err := strconv.ParseUint(s, 10, 0)
+		if err != nil {
+			return fmt.Errorf("invalid log level: %q", s)
+		}
+		*l = LogLevel(v)
+	}
+	return nil
+}
+
 // convertLevel returns the slog.Level representation of the given LogLevel, where possible, otherwise defaultSlogLevel.
 func convertLevel(level LogLevel) slog.Level {
 	switch level {
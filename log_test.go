@@ -0,0 +1,157 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogInfo_Visibility(t *testing.T) {
+	prob := problem.New(
+		problem.WithDetail("pq: connection refused", problem.FlagLog),
+		problem.WithExtension("region", "eu-west-1"),
+		problem.WithExtensionLogOnly("query", "SELECT * FROM users"),
+	)
+
+	data, err := json.Marshal(prob)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "connection refused")
+	assert.NotContains(t, string(data), "SELECT")
+	assert.Empty(t, prob.Detail)
+	assert.Equal(t, map[string]any{"region": "eu-west-1"}, map[string]any(prob.Extensions))
+
+	info := prob.LogInfo()
+	assert.Equal(t, "pq: connection refused", info.Detail)
+	assert.Equal(t, map[string]any{"query": "SELECT * FROM users", "region": "eu-west-1"}, info.Extensions)
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("failed", "problem", prob)
+	var entry struct {
+		Problem struct {
+			Detail     string         `json:"detail"`
+			Extensions map[string]any `json:"extensions"`
+		} `json:"problem"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "pq: connection refused", entry.Problem.Detail)
+	assert.Equal(t, map[string]any{"query": "SELECT * FROM users", "region": "eu-west-1"}, entry.Problem.Extensions)
+}
+
+func TestLogInfo_VisibilityFieldOnly(t *testing.T) {
+	prob := problem.New(problem.WithDetail("Your balance is 30", problem.FlagField))
+
+	assert.Equal(t, "Your balance is 30", prob.Detail)
+	assert.Empty(t, prob.LogInfo().Detail)
+}
+
+func TestLogInfo_VisibilityDefault(t *testing.T) {
+	prob := problem.New(problem.WithDetail("Your balance is 30"), problem.WithExtension("balance", 30))
+
+	info := prob.LogInfo()
+	assert.Equal(t, "Your balance is 30", info.Detail)
+	assert.Equal(t, map[string]any{"balance": 30}, info.Extensions)
+
+	prob.Detail = "Your balance is 20"
+	assert.Equal(t, "Your balance is 20", prob.LogInfo().Detail)
+}
+
+func TestLogInfo_VisibilityOverlap(t *testing.T) {
+	prob := problem.Build().
+		Extension("query", "public").
+		ExtensionLogOnly("query", "secret").
+		Problem()
+	assert.Empty(t, prob.Extensions)
+	assert.Equal(t, map[string]any{"query": "secret"}, prob.LogInfo().Extensions)
+
+	prob = problem.Build().
+		ExtensionLogOnly("query", "secret").
+		Extension("query", "public").
+		Problem()
+	assert.Equal(t, map[string]any{"query": "public"}, map[string]any(prob.Extensions))
+	assert.Equal(t, map[string]any{"query": "public"}, prob.LogInfo().Extensions)
+}
+
+func TestLogInfo_VisibilityWrapped(t *testing.T) {
+	cause := problem.New(
+		problem.WithDetail("pq: connection refused", problem.FlagLog),
+		problem.WithExtensionLogOnly("query", "SELECT * FROM users"),
+	)
+	prob := problem.New(problem.Wrap(fmt.Errorf("outer: %w", cause), problem.FullUnwrapper()))
+
+	assert.Empty(t, prob.Detail)
+	assert.Empty(t, prob.Extensions)
+	info := prob.LogInfo()
+	assert.Equal(t, "pq: connection refused", info.Detail)
+	assert.Equal(t, map[string]any{"query": "SELECT * FROM users"}, info.Extensions)
+}
+
+func TestLogLevel_MarshalText(t *testing.T) {
+	tests := map[problem.LogLevel]string{
+		0:                     "",
+		problem.LogLevelDebug: "DEBUG",
+		problem.LogLevelInfo:  "INFO",
+		problem.LogLevelWarn:  "WARN",
+		problem.LogLevelError: "ERROR",
+		problem.LogLevel(42):  "42",
+	}
+	for level, expected := range tests {
+		b, err := level.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(b))
+	}
+}
+
+func TestLogLevel_UnmarshalText(t *testing.T) {
+	tests := map[string]problem.LogLevel{
+		"":        0,
+		"debug":   problem.LogLevelDebug,
+		"INFO":    problem.LogLevelInfo,
+		"Warning": problem.LogLevelWarn,
+		"error":   problem.LogLevelError,
+		"42":      problem.LogLevel(42),
+	}
+	for text, expected := range tests {
+		var level problem.LogLevel
+		require.NoError(t, level.UnmarshalText([]byte(text)), text)
+		assert.Equal(t, expected, level, text)
+	}
+
+	var level problem.LogLevel
+	assert.EqualError(t, level.UnmarshalText([]byte("loud")), `invalid log level: "loud"`)
+}
+
+func TestLogLevel_JSON(t *testing.T) {
+	b, err := json.Marshal(problem.Type{LogLevel: problem.LogLevelWarn})
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"logLevel":"WARN"`)
+
+	var typ problem.Type
+	require.NoError(t, json.Unmarshal([]byte(`{"logLevel":"error"}`), &typ))
+	assert.Equal(t, problem.LogLevelError, typ.LogLevel)
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"context"
+	"errors"
+
+	"github.com/neocotic/go-optional"
+)
+
+type (
+	// Mapper contains an ordered list of MapperRule used to declaratively map errors that are not problems to a
+	// Definition, along with any options derived from the error.
+	//
+	// When assigned to Generator.Mapper, it is used any time an error is wrapped (e.g. via Builder.Wrap or Wrap) that
+	// does not already contain a Problem in its tree, as well as by Generator.WriteError and the Middleware functions
+	// before falling back to the function provided to them.
+	//
+	// For example;
+	//
+	//	gen := &problem.Generator{
+	//		Mapper: problem.NewMapper(
+	//			problem.MapIs(sql.ErrNoRows, http.NotFoundDefinition),
+	//			problem.MapAs[*OutOfCreditError](OutOfCreditDefinition,
+	//				problem.MapDetailFromError(),
+	//				problem.MapExtensionFromError("balance", func(err error) any {
+	//					return err.(*OutOfCreditError).Balance
+	//				}),
+	//			),
+	//		),
+	//	}
+	//	prob := gen.New(problem.Wrap(err))
+	//
+	// The zero value is ready to use but contains no rules so will not map any error.
+	Mapper struct {
+		// Rules contains the MapperRule used to map errors, with the first one to match an error being used.
+		Rules []MapperRule
+	}
+
+	// MapperRule is a single rule within a Mapper that maps any matching error to a Definition, along with any options
+	// derived from the matched error.
+	//
+	// A MapperRule can only be created using MapAs, MapFunc, or MapIs.
+	MapperRule struct {
+		// def is the Definition to be used to construct a Problem for a matched error.
+		def Definition
+		// match returns the error to be passed to each MapOption if the given error is matched by the rule.
+		match func(err error) (error, bool)
+		// opts contains the MapOption used to derive options from a matched error.
+		opts []MapOption
+	}
+
+	// MapOption is a function used by a MapperRule to derive options from a matched error (e.g. detail or extensions)
+	// to be used when constructing a Problem for it.
+	//
+	// An Option returned by a MapOption must not wrap an error (e.g. via Wrap) as the matched error is always wrapped.
+	MapOption func(err error) []Option
+)
+
+// MapAs returns a MapperRule that maps any error whose tree contains an error of type E (see errors.As) to the given
+// Definition, optionally along with any options derived from the error of type E by the MapOption provided.
+func MapAs[E error](def Definition, opts ...MapOption) MapperRule {
+	return MapperRule{
+		def: def,
+		match: func(err error) (error, bool) {
+			var target E
+			if errors.As(err, &target) {
+				return target, true
+			}
+			return nil, false
+		},
+		opts: opts,
+	}
+}
+
+// MapDetailFromError returns a MapOption that uses the message of the matched error as the detail. See WithDetail for
+// more information.
+//
+// Since error messages often contain information that is not safe to be exposed to clients, MapDetailFromError should
+// only be used for errors whose messages are intended to be read by clients.
+func MapDetailFromError() MapOption {
+	return func(err error) []Option {
+		return []Option{WithDetail(err.Error())}
+	}
+}
+
+// MapExtensionFromError returns a MapOption that uses the value returned by the given function for the matched error as
+// an extension with the key provided. See WithExtension for more information.
+//
+// When used with MapAs, the matched error is always of the type used, so it can be safely asserted.
+func MapExtensionFromError(key string, fn func(err error) any) MapOption {
+	return func(err error) []Option {
+		return []Option{WithExtension(key, fn(err))}
+	}
+}
+
+// MapFunc returns a MapperRule that maps any error for which the given function returns true to the Definition
+// provided, optionally along with any options derived from the error by the MapOption provided.
+func MapFunc(fn func(err error) bool, def Definition, opts ...MapOption) MapperRule {
+	return MapperRule{
+		def: def,
+		match: func(err error) (error, bool) {
+			return err, fn(err)
+		},
+		opts: opts,
+	}
+}
+
+// MapIs returns a MapperRule that maps any error whose tree contains an error that matches target (see errors.Is) to
+// the given Definition, optionally along with any options derived from the error by the MapOption provided.
+//
+// Unlike MapAs, the error passed to each MapOption is the error being mapped rather than target, as target is
+// typically a sentinel error and contains less information.
+func MapIs(target error, def Definition, opts ...MapOption) MapperRule {
+	return MapperRule{
+		def: def,
+		match: func(err error) (error, bool) {
+			return err, errors.Is(err, target)
+		},
+		opts: opts,
+	}
+}
+
+// MapOptions returns a MapOption that always returns the given options, regardless of the matched error.
+func MapOptions(opts ...Option) MapOption {
+	return func(_ error) []Option {
+		return opts
+	}
+}
+
+// NewMapper returns a Mapper containing the given rules.
+func NewMapper(rules ...MapperRule) *Mapper {
+	return &Mapper{Rules: rules}
+}
+
+// Map returns the options to be used to construct a Problem for the given error from the first of Mapper.Rules to match
+// it, if any, along with whether it was matched. Map can be safely called on a nil Mapper, which never matches.
+//
+// The options consist of FromDefinition for the Definition of the matched MapperRule followed by any options derived
+// from the matched error by its MapOption. They never wrap err, which is the responsibility of the caller.
+func (m *Mapper) Map(err error) ([]Option, bool) {
+	rule, matched, ok := m.match(err)
+	if !ok {
+		return nil, false
+	}
+	opts := []Option{FromDefinition(rule.def)}
+	for _, mapOpt := range rule.opts {
+		opts = append(opts, mapOpt(matched)...)
+	}
+	return opts, true
+}
+
+// Matches returns whether the given error is matched by any of Mapper.Rules. Matches can be safely called on a nil
+// Mapper, which never matches.
+func (m *Mapper) Matches(err error) bool {
+	_, _, matched := m.match(err)
+	return matched
+}
+
+// match returns the first of Mapper.Rules to match the given error, if any, along with the matched error.
+func (m *Mapper) match(err error) (MapperRule, error, bool) {
+	if m == nil || err == nil {
+		return MapperRule{}, nil, false
+	}
+	for _, rule := range m.Rules {
+		if rule.match == nil {
+			continue
+		}
+		if matched, ok := rule.match(err); ok {
+			return rule, matched, true
+		}
+	}
+	return MapperRule{}, nil, false
+}
+
+// mapError returns a Problem constructed from the first of Generator.Mapper.Rules to match the given error, if any,
+// along with its Definition.
+//
+// The returned Problem contains no stack trace, "UUID", or wrapped error as it is only intended to be used as a source
+// of fields, in the same way as a Problem unwrapped by an Unwrapper.
+func (g *Generator) mapError(ctx context.Context, err error) (*Problem, Definition, bool) {
+	opts, ok := g.Mapper.Map(err)
+	if !ok {
+		return nil, Definition{}, false
+	}
+	b := &Builder{Generator: g, ctx: optional.Of(ctx)}
+	b.Stack(FlagDisable).UUID(FlagDisable)
+	for _, opt := range opts {
+		opt(b)
+	}
+	def := b.def
+	// Mapped problems must not be mapped again so the error is ignored
+	b.errs = nil
+	b.problem = Problem{}
+	return b.build(0), def, true
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type outOfCreditError struct {
+	Balance int
+}
+
+func (e *outOfCreditError) Error() string {
+	return fmt.Sprintf("your current balance is %d", e.Balance)
+}
+
+var (
+	notFoundDefinition = problem.Definition{
+		Type: problem.Type{Status: http.StatusNotFound, Title: "Not Found"},
+	}
+	outOfCreditDefinition = problem.Definition{
+		Type: problem.Type{
+			Status: http.StatusForbidden,
+			Title:  "You do not have enough credit.",
+			URI:    "https://example.com/probs/out-of-credit",
+		},
+	}
+)
+
+func newMapperGenerator() *problem.Generator {
+	return &problem.Generator{
+		Mapper: problem.NewMapper(
+			problem.MapIs(sql.ErrNoRows, notFoundDefinition, problem.MapOptions(problem.WithCode(404, "DB"))),
+			problem.MapAs[*outOfCreditError](outOfCreditDefinition,
+				problem.MapDetailFromError(),
+				problem.MapExtensionFromError("balance", func(err error) any {
+					return err.(*outOfCreditError).Balance
+				}),
+			),
+			problem.MapFunc(func(err error) bool {
+				return err.Error() == "teapot"
+			}, problem.Definition{Type: problem.Type{Status: http.StatusTeapot}}),
+		),
+	}
+}
+
+func TestMapper_Wrap(t *testing.T) {
+	gen := newMapperGenerator()
+
+	err := fmt.Errorf("find user: %w", sql.ErrNoRows)
+	prob := gen.New(problem.Wrap(err))
+	assert.Equal(t, http.StatusNotFound, prob.Status)
+	assert.Equal(t, "Not Found", prob.Title)
+	assert.Equal(t, problem.Code("DB-404"), prob.Code)
+	assert.ErrorIs(t, prob, sql.ErrNoRows)
+	assert.True(t, problem.IsDefinition(prob, notFoundDefinition))
+
+	err = fmt.Errorf("charge: %w", &outOfCreditError{Balance: 30})
+	prob = gen.New(problem.Wrap(err))
+	assert.Equal(t, http.StatusForbidden, prob.Status)
+	assert.Equal(t, "https://example.com/probs/out-of-credit", prob.Type)
+	assert.Equal(t, "your current balance is 30", prob.Detail)
+	assert.Equal(t, 30, prob.Extensions["balance"])
+
+	prob = gen.New(problem.Wrap(errors.New("teapot")))
+	assert.Equal(t, http.StatusTeapot, prob.Status)
+
+	prob = gen.New(problem.Wrap(errors.New("boom")))
+	assert.Equal(t, http.StatusInternalServerError, prob.Status)
+}
+
+func TestMapper_Map(t *testing.T) {
+	mapper := newMapperGenerator().Mapper
+
+	opts, mapped := mapper.Map(fmt.Errorf("charge: %w", &outOfCreditError{Balance: 30}))
+	require.True(t, mapped)
+	prob := problem.New(opts...)
+	assert.Equal(t, http.StatusForbidden, prob.Status)
+	assert.Equal(t, "your current balance is 30", prob.Detail)
+	assert.Equal(t, 30, prob.Extensions["balance"])
+	assert.True(t, problem.IsDefinition(prob, outOfCreditDefinition))
+	assert.Nil(t, errors.Unwrap(prob))
+
+	_, mapped = mapper.Map(errors.New("boom"))
+	assert.False(t, mapped)
+	_, mapped = (*problem.Mapper)(nil).Map(sql.ErrNoRows)
+	assert.False(t, mapped)
+}
+
+func TestMapper_Precedence(t *testing.T) {
+	gen := newMapperGenerator()
+	err := &outOfCreditError{Balance: 30}
+
+	prob := gen.New(problem.WithDetail("Top up your account"), problem.Wrap(err), problem.WithStatus(http.StatusPaymentRequired))
+	assert.Equal(t, http.StatusPaymentRequired, prob.Status)
+	assert.Equal(t, "Top up your account", prob.Detail)
+	assert.Equal(t, 30, prob.Extensions["balance"])
+
+	prob = gen.New(problem.Wrap(err), problem.FromDefinition(notFoundDefinition))
+	assert.Equal(t, http.StatusNotFound, prob.Status)
+	assert.Empty(t, prob.Extensions)
+
+	wrapped := gen.New(problem.WithStatus(http.StatusConflict), problem.Wrap(err))
+	prob = gen.New(problem.Wrap(wrapped))
+	assert.Equal(t, http.StatusInternalServerError, prob.Status)
+}
+
+func TestMapper_WriteError(t *testing.T) {
+	gen := newMapperGenerator()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	w := httptest.NewRecorder()
+	err := gen.WriteError(&outOfCreditError{Balance: 30}, w, req, func(err error) *problem.Problem {
+		t.Fatal("probFunc must not be called for mapped errors")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, float64(30), body["balance"])
+
+	w = httptest.NewRecorder()
+	err = gen.WriteError(errors.New("boom"), w, req, func(err error) *problem.Problem {
+		return gen.New(problem.WithStatus(http.StatusBadGateway), problem.Wrap(err))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+
+	w = httptest.NewRecorder()
+	require.NoError(t, gen.WriteError(errors.New("boom"), w, req, nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestMapper_WriteErrorHTML(t *testing.T) {
+	gen := newMapperGenerator()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	w := httptest.NewRecorder()
+	err := gen.WriteErrorHTML(&outOfCreditError{Balance: 30}, w, req, func(err error) *problem.Problem {
+		t.Fatal("probFunc must not be called for mapped errors")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, problem.ContentTypeHTMLUTF8, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "<dt>balance</dt><dd>30</dd>")
+
+	w = httptest.NewRecorder()
+	require.NoError(t, gen.WriteErrorHTML(errors.New("boom"), w, req, nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestMapper_Middleware(t *testing.T) {
+	gen := newMapperGenerator()
+	handler := problem.MiddlewareUsing(gen, nil)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(fmt.Errorf("find user: %w", sql.ErrNoRows))
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -22,8 +22,9 @@ package problem
 
 import (
 	"cmp"
-	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 )
 
 type (
@@ -54,13 +55,14 @@ const (
 
 // As is a convenient shorthand for calling errors.As with a Problem target, however, it also gracefully handles the
 // case where err is nil without a panic.
+//
+// Unlike errors.As, any error within err's tree that describes itself (see Describer and the other describer
+// interfaces) is also treated as a Problem.
 func As(err error) (*Problem, bool) {
 	if err == nil {
 		return nil, false
 	}
-	var p *Problem
-	isProblem := errors.As(err, &p)
-	return p, isProblem
+	return findProblem(nil, err, nil)
 }
 
 // AsOrElse is a convenient shorthand for calling errors.As with a Problem target, however, it also gracefully handles
@@ -74,8 +76,7 @@ func AsOrElse(err error, defaultProb *Problem) (*Problem, bool) {
 	if err == nil {
 		return defaultProb, false
 	}
-	var p *Problem
-	isProblem := errors.As(err, &p)
+	p, isProblem := findProblem(nil, err, nil)
 	if !isProblem {
 		p = defaultProb
 	}
@@ -93,8 +94,7 @@ func AsOrElseGet(err error, defaultProbFunc func() *Problem) (*Problem, bool) {
 	if err == nil {
 		return defaultProbFunc(), false
 	}
-	var p *Problem
-	isProblem := errors.As(err, &p)
+	p, isProblem := findProblem(nil, err, nil)
 	if !isProblem {
 		p = defaultProbFunc()
 	}
@@ -106,22 +106,15 @@ func AsOrElseGet(err error, defaultProbFunc func() *Problem) (*Problem, bool) {
 //
 // Additionally, if a Problem is found in err's tree, it must match all matchers provided, otherwise it will be
 // unwrapped, and it's tree (excluding itself) will continue to be checked until either a matching Problem is found or
-// no Problem is found.
+// no Problem is found. Like As, any error that describes itself (see Describer) is also treated as a Problem, in which
+// case only the tree of the describing error continues to be checked if it does not match.
 func AsMatch(err error, matchers ...Matcher) (*Problem, bool) {
 	if err == nil {
 		return nil, false
 	}
-	var p *Problem
-	if !errors.As(err, &p) {
-		return nil, false
-	}
-	if Match(p, matchers...) {
-		return p, true
-	}
-	if p == nil {
-		return nil, false
-	}
-	return AsMatch(p.Unwrap(), matchers...)
+	return findProblem(nil, err, func(p *Problem) bool {
+		return Match(p, matchers...)
+	})
 }
 
 // AsMatchOrElse is a convenient shorthand for calling errors.As with a Problem target, however, it also gracefully
@@ -168,6 +161,18 @@ func Is(err error) bool {
 	return isProblem
 }
 
+// IsDefinition acts as a substitute for errors.Is, returning true if err's tree contains a Problem that was generated
+// from, or decoded with a type URI reference registered against, the given Definition.
+//
+// It is effectively a convenient shorthand for calling IsMatch with HasDefinition. For example;
+//
+//	if problem.IsDefinition(err, http.NotFoundDefinition) {
+//		// ...
+//	}
+func IsDefinition(err error, def Definition) bool {
+	return IsMatch(err, HasDefinition(def))
+}
+
 // IsMatch acts as a substitute for errors.Is, returning true if err's tree contains a Problem that matches all matchers
 // provided.
 //
@@ -248,6 +253,19 @@ func HasCodeValueUsing(gen *Generator, value uint, operator ...Operator) Matcher
 	}
 }
 
+// HasDefinition is used to match a Problem based on whether it was generated from, or decoded with a type URI reference
+// registered against, the given Definition. See Problem.Definition for more information.
+//
+// Definitions are considered equal if all of their fields are deeply equal.
+func HasDefinition(def Definition) Matcher {
+	return func(p *Problem) bool {
+		if pDef, found := p.Definition(); found {
+			return reflect.DeepEqual(pDef, def)
+		}
+		return false
+	}
+}
+
 // HasDetail is used to match a Problem based on its detail.
 //
 // By default, this match is based on whether the values are equal, however, this can be controlled by passing another
@@ -259,6 +277,16 @@ func HasDetail(detail string, operator ...Operator) Matcher {
 	}
 }
 
+// HasErrorAt is used to match a Problem based on whether it contains a FieldError for the given JSON Pointer. See
+// Problem.FieldErrors for more information.
+func HasErrorAt(pointer string) Matcher {
+	return func(p *Problem) bool {
+		return slices.ContainsFunc(p.FieldErrors(), func(fe FieldError) bool {
+			return fe.Pointer == pointer
+		})
+	}
+}
+
 // HasExtension is used to match a Problem based on whether it contains an extension with the given key.
 func HasExtension(key string) Matcher {
 	return func(p *Problem) bool {
@@ -308,6 +336,15 @@ func HasStack() Matcher {
 	}
 }
 
+// HasRetryAfter is used to match a Problem based on whether it has a retry delay. See Problem.RetryAfter for more
+// information.
+func HasRetryAfter() Matcher {
+	return func(p *Problem) bool {
+		_, found := p.RetryAfter()
+		return found
+	}
+}
+
 // HasStatus is used to match a Problem based on its status.
 //
 // By default, this match is based on whether the values are equal, however, this can be controlled by passing another
@@ -319,6 +356,14 @@ func HasStatus(status int, operator ...Operator) Matcher {
 	}
 }
 
+// HasStatusClass is used to match a Problem based on the class of its status, which is the first digit of the status
+// (e.g. 5 for a 503 Service Unavailable).
+func HasStatusClass(class int) Matcher {
+	return func(p *Problem) bool {
+		return p.Status/100 == class
+	}
+}
+
 // HasTitle is used to match a Problem based on its title.
 //
 // By default, this match is based on whether the values are equal, however, this can be controlled by passing another
@@ -348,6 +393,13 @@ func HasUUID() Matcher {
 	}
 }
 
+// IsRetryable is used to match a Problem based on whether it is retryable. See Problem.Retryable for more information.
+func IsRetryable() Matcher {
+	return func(p *Problem) bool {
+		return p.Retryable()
+	}
+}
+
 // Match returns whether the given Problem matchers all the matchers provided.
 //
 // If one or more Matcher is provided but prob is nil, false will always be returned as a Matcher assumes prob is not
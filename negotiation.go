@@ -0,0 +1,150 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/jay-babu/go-problem/internal/accept"
+)
+
+// Negotiation provides control over whether the content/media type used to write a Problem to an HTTP response is
+// negotiated using the Accept header of the HTTP request.
+//
+// Negotiation only applies to functions that are not bound to a specific format (e.g. Generator.WriteError,
+// Generator.WriteProblem, and the Middleware functions) and only when WriteOptions.ContentType is not provided, as an
+// explicit content/media type always takes precedence.
+type Negotiation uint8
+
+const (
+	// NegotiationDisabled disables content negotiation so that the content/media type is derived solely from
+	// WriteOptions.ContentType and Generator.ContentType.
+	NegotiationDisabled Negotiation = iota
+	// NegotiationLenient negotiates the content/media type using the Accept header of the HTTP request, falling back to
+	// the default content/media type if none of the supported content/media types are acceptable.
+	NegotiationLenient
+	// NegotiationStrict negotiates the content/media type using the Accept header of the HTTP request and, if none of the
+	// supported content/media types are acceptable, responds with http.StatusNotAcceptable instead, where the body
+	// contains a Problem describing the failed negotiation in the default content/media type.
+	NegotiationStrict
+)
+
+const (
+	// acceptHeader is the header representing the content/media types acceptable to the client of an HTTP request.
+	acceptHeader = "Accept"
+	// varyHeader is the header representing the HTTP request headers that were used to select an HTTP response.
+	varyHeader = "Vary"
+)
+
+// negotiableContentTypes contains the content/media type to be used for each media type that may be negotiated, in
+// order of preference, after the default content/media type.
+var negotiableContentTypes = []struct {
+	mediaType   string
+	contentType string
+}{
+	{ContentTypeJSON, ContentTypeJSONUTF8},
+	{ContentTypeXML, ContentTypeXMLUTF8},
+	{ContentTypeGenericJSON, ContentTypeGenericJSONUTF8},
+	{ContentTypeGenericXML, ContentTypeGenericXMLUTF8},
+	{ContentTypeHTML, ContentTypeHTMLUTF8},
+}
+
+// negotiateContentType returns the most suitable content/media type based on the Accept header of the given HTTP
+// request, giving preference to defaultCT when more than one content/media type is equally acceptable.
+//
+// If none of the supported content/media types are acceptable, defaultCT is returned along with false.
+func (g *Generator) negotiateContentType(req *http.Request, defaultCT string) (string, bool) {
+	defaultMT := mediaTypeOf(defaultCT)
+	offers := make([]string, 0, len(negotiableContentTypes)+1)
+	offers = append(offers, defaultMT)
+	for _, nct := range negotiableContentTypes {
+		if nct.mediaType != defaultMT {
+			offers = append(offers, nct.mediaType)
+		}
+	}
+
+	mt, acceptable := accept.NegotiateMediaType(strings.Join(req.Header.Values(acceptHeader), ","), offers)
+	if !acceptable || mt == defaultMT {
+		return defaultCT, acceptable
+	}
+	for _, nct := range negotiableContentTypes {
+		if nct.mediaType == mt {
+			return nct.contentType, true
+		}
+	}
+	// Sanity check - should never happen
+	return defaultCT, true
+}
+
+// negotiateWriteOptions applies the given WriteOptions on top of defaults, and then negotiates the content/media type
+// using the Accept header of the HTTP request, based on Generator.Negotiation, if WriteOptions.ContentType was not
+// explicitly provided.
+//
+// false is returned only if Generator.Negotiation is NegotiationStrict and none of the supported content/media types
+// are acceptable, in which case the returned WriteOptions contains the default content/media type.
+func (g *Generator) negotiateWriteOptions(w http.ResponseWriter, req *http.Request, defaults WriteOptions, opts []WriteOptions) (WriteOptions, bool) {
+	_opts := defaults.apply(opts, isValidContentType)
+	if g.Negotiation == NegotiationDisabled || (len(opts) > 0 && isValidContentType(opts[0].ContentType)) {
+		return _opts, true
+	}
+
+	w.Header().Add(varyHeader, acceptHeader)
+
+	var acceptable bool
+	_opts.ContentType, acceptable = g.negotiateContentType(req, _opts.ContentType)
+	return _opts, acceptable || g.Negotiation != NegotiationStrict
+}
+
+// notAcceptable returns a Problem describing a failed content negotiation, wrapping the Problem that could not be
+// written.
+func (g *Generator) notAcceptable(ctx context.Context, prob *Problem) *Problem {
+	return g.BuildContext(ctx).
+		Status(http.StatusNotAcceptable).
+		Title(http.StatusText(http.StatusNotAcceptable)).
+		TitleKey("problem.http.NotAcceptable.title").
+		Detail("None of the content types supported for the response are acceptable").
+		DetailKey("problem.http.NotAcceptableDefinition.detail").
+		LogLevel(LogLevelDebug).
+		Wrap(prob, NoopUnwrapper()).
+		Problem()
+}
+
+// writeNotAcceptable logs the given Problem, where appropriate, before writing an HTTP response for a Problem
+// describing a failed content negotiation using WriteOptions, that are expected to have been applied.
+//
+// An error is returned if the Problem fails to be written to w.
+func (g *Generator) writeNotAcceptable(prob *Problem, w http.ResponseWriter, req *http.Request, opts WriteOptions) error {
+	if !opts.LogDisabled && opts.LogMessage != "" {
+		g.LogContext(req.Context(), opts.LogMessage, g.localizeForLog(req.Context(), prob), opts.LogArgs...)
+	}
+
+	opts.LogDisabled = true
+	opts.Status = http.StatusNotAcceptable
+	return g.writeProblem(g.notAcceptable(req.Context(), prob), w, req, opts)
+}
+
+// mediaTypeOf returns the lower-cased media type of the given content/media type without any parameters.
+func mediaTypeOf(ct string) string {
+	mt, _, _ := strings.Cut(ct, ";")
+	return strings.ToLower(strings.TrimSpace(mt))
+}
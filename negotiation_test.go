@@ -0,0 +1,102 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeNegotiated(t *testing.T, negotiation problem.Negotiation, accept string, opts problem.WriteOptions) *httptest.ResponseRecorder {
+	t.Helper()
+	gen := &problem.Generator{Negotiation: negotiation}
+	prob := gen.New(problem.WithStatus(http.StatusConflict), problem.WithTitle("Conflict"))
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	opts.LogDisabled = true
+	require.NoError(t, gen.WriteProblem(prob, rec, req, opts))
+	return rec
+}
+
+func TestWriteProblem_NegotiationDisabled(t *testing.T) {
+	rec := writeNegotiated(t, problem.NegotiationDisabled, "application/xml", problem.WriteOptions{})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, problem.ContentTypeJSONUTF8, rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Header().Values("Vary"))
+}
+
+func TestWriteProblem_NegotiationLenient(t *testing.T) {
+	tests := map[string]string{
+		"":                                  problem.ContentTypeJSONUTF8,
+		"*/*":                               problem.ContentTypeJSONUTF8,
+		"application/problem+xml":           problem.ContentTypeXMLUTF8,
+		"application/xml;q=0.9, */*;q=0.1":  problem.ContentTypeGenericXMLUTF8,
+		"application/json, application/xml": problem.ContentTypeGenericJSONUTF8,
+		"text/plain":                        problem.ContentTypeJSONUTF8,
+	}
+	for accept, expected := range tests {
+		rec := writeNegotiated(t, problem.NegotiationLenient, accept, problem.WriteOptions{})
+		assert.Equal(t, http.StatusConflict, rec.Code, accept)
+		assert.Equal(t, expected, rec.Header().Get("Content-Type"), accept)
+		assert.Equal(t, []string{"Accept"}, rec.Header().Values("Vary"), accept)
+	}
+
+	rec := writeNegotiated(t, problem.NegotiationLenient, "application/problem+xml", problem.WriteOptions{})
+	var prob problem.Problem
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &prob))
+	assert.Equal(t, http.StatusConflict, prob.Status)
+}
+
+func TestWriteProblem_NegotiationStrict(t *testing.T) {
+	rec := writeNegotiated(t, problem.NegotiationStrict, "application/problem+xml", problem.WriteOptions{})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, problem.ContentTypeXMLUTF8, rec.Header().Get("Content-Type"))
+	assert.Equal(t, []string{"Accept"}, rec.Header().Values("Vary"))
+
+	rec = writeNegotiated(t, problem.NegotiationStrict, "text/plain", problem.WriteOptions{})
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+	assert.Equal(t, problem.ContentTypeJSONUTF8, rec.Header().Get("Content-Type"))
+	assert.Equal(t, []string{"Accept"}, rec.Header().Values("Vary"))
+
+	var prob problem.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &prob))
+	assert.Equal(t, http.StatusNotAcceptable, prob.Status)
+	assert.Equal(t, http.StatusText(http.StatusNotAcceptable), prob.Title)
+}
+
+func TestWriteProblem_NegotiationExplicitContentType(t *testing.T) {
+	rec := writeNegotiated(t, problem.NegotiationStrict, "text/plain", problem.WriteOptions{
+		ContentType: problem.ContentTypeXMLUTF8,
+	})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, problem.ContentTypeXMLUTF8, rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Header().Values("Vary"))
+}
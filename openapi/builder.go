@@ -0,0 +1,308 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/jay-babu/go-problem/catalog"
+)
+
+type (
+	// Builder is used to generate Components from problem.Definition values.
+	Builder struct {
+		// Generator is the problem.Generator used to build the example problem for each definition, allowing any
+		// translation keys to be resolved, for example.
+		//
+		// If nil, problem.DefaultGenerator is used.
+		Generator *problem.Generator
+		// MediaTypes contains the content types of each Response.
+		//
+		// If empty, only problem.ContentTypeJSON is used.
+		MediaTypes []string
+	}
+
+	// entry contains everything needed to generate the schema and response for a single definition.
+	entry struct {
+		def     problem.Definition
+		members []catalog.Member
+		name    string
+	}
+)
+
+const (
+	// ProblemDetailsSchemaName is the name of the Schema describing all members emitted by the problem package, which
+	// is referenced by the Schema of each definition.
+	ProblemDetailsSchemaName = "ProblemDetails"
+	// schemaRefPrefix is the prefix of a reference to a Schema within Components.
+	schemaRefPrefix = "#/components/schemas/"
+	// extensionPrefix is the prefix with which the key of every specification extension must start.
+	extensionPrefix = "x-"
+	// extensionCode is the specification extension containing the code of a definition, if any.
+	extensionCode = "x-problem-code"
+	// extensionGoType is the specification extension containing the Go type of a declared extension member.
+	extensionGoType = "x-go-type"
+	// extensionLinks is the specification extension containing the documentation links of a definition, if any.
+	extensionLinks = "x-problem-links"
+	// extensionLogLevel is the specification extension containing the log level of a definition, if any.
+	extensionLogLevel = "x-problem-log-level"
+	// extensionOwner is the specification extension containing the owner of a definition, if any.
+	extensionOwner = "x-problem-owner"
+	// extensionResolution is the specification extension containing the resolution steps of a definition, if any.
+	extensionResolution = "x-problem-resolution"
+	// extensionTags is the specification extension containing the tags of a definition, if any.
+	extensionTags = "x-problem-tags"
+)
+
+// Components returns Components containing the ProblemDetails Schema along with a Schema and Response for each of the
+// given definitions, mapped to their names.
+//
+// Since definitions do not declare their extension members, the schema of each extension is inferred from its default
+// value within problem.Definition.Extensions, if any. Builder.Catalog may be preferred for catalogs that declare their
+// extension members.
+func (b Builder) Components(defs map[string]problem.Definition) Components {
+	entries := make([]entry, 0, len(defs))
+	for _, name := range slices.Sorted(maps.Keys(defs)) {
+		entries = append(entries, entry{def: defs[name], name: name})
+	}
+	return b.components(entries)
+}
+
+// Catalog returns Components containing the ProblemDetails Schema along with a Schema and Response for each definition
+// within the given catalog.Catalog.
+//
+// The schema of each extension member declared by a definition (see catalog.DefinitionEntry.Members) is derived from
+// its Go type, with the Go type itself being included as the "x-go-type" specification extension. The schema of any
+// other extension is inferred from its default value within problem.Definition.Extensions.
+func (b Builder) Catalog(c *catalog.Catalog) Components {
+	names := c.DefinitionNames()
+	entries := make([]entry, 0, len(names))
+	for _, name := range names {
+		e, _ := c.Entry(name)
+		entries = append(entries, entry{def: e.Definition, members: e.Members, name: name})
+	}
+	return b.components(entries)
+}
+
+// components returns Components containing the ProblemDetails Schema along with a Schema and Response for each of the
+// given entries.
+func (b Builder) components(entries []entry) Components {
+	gen := b.Generator
+	if gen == nil {
+		gen = problem.DefaultGenerator
+	}
+	mediaTypes := b.MediaTypes
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{problem.ContentTypeJSON}
+	}
+
+	c := Components{
+		Responses: make(map[string]*Response, len(entries)),
+		Schemas:   map[string]*Schema{ProblemDetailsSchemaName: ProblemDetailsSchema()},
+	}
+	for _, e := range entries {
+		example := exampleProblem(gen, e)
+		c.Schemas[e.name] = definitionSchema(e, example)
+
+		res := &Response{
+			Content:     make(map[string]*MediaType, len(mediaTypes)),
+			Description: example.Title,
+		}
+		for _, mediaType := range mediaTypes {
+			mt := &MediaType{Schema: &Schema{Ref: schemaRefPrefix + e.name}}
+			if strings.HasSuffix(mediaType, "json") {
+				mt.Example = exampleBody(example)
+			}
+			res.Content[mediaType] = mt
+		}
+		if e.def.Code != "" {
+			res.Extensions = map[string]any{extensionCode: string(e.def.Code)}
+		}
+		c.Responses[e.name] = res
+	}
+	return c
+}
+
+// FromCatalog is a convenient shorthand for calling Builder.Catalog on a Builder using problem.DefaultGenerator.
+func FromCatalog(c *catalog.Catalog) Components {
+	return Builder{}.Catalog(c)
+}
+
+// FromDefinitions is a convenient shorthand for calling Builder.Components on a Builder using
+// problem.DefaultGenerator.
+func FromDefinitions(defs map[string]problem.Definition) Components {
+	return Builder{}.Components(defs)
+}
+
+// ProblemDetailsSchema returns a Schema describing all members emitted by the problem package, including those that
+// are not defined by RFC 9457 (i.e. "code", "stack", and "uuid"), while allowing additional extension members.
+func ProblemDetailsSchema() *Schema {
+	minStatus, maxStatus := 100, 599
+	return &Schema{
+		AdditionalProperties: true,
+		Description:          "Problem details for HTTP APIs as defined by RFC 9457.",
+		Properties: map[string]*Schema{
+			"code": {
+				Description: "A unique code that identifies the specific occurrence of the problem.",
+				Type:        "string",
+			},
+			"detail": {
+				Description: "A human-readable explanation specific to this occurrence of the problem.",
+				Type:        "string",
+			},
+			"instance": {
+				Description: "A URI reference that identifies the specific occurrence of the problem.",
+				Format:      "uri-reference",
+				Type:        "string",
+			},
+			"stack": {
+				Description: "A stack trace captured when the problem occurred, where enabled.",
+				Type:        "string",
+			},
+			"status": {
+				Description: "The HTTP status code generated by the origin server for this occurrence of the problem.",
+				Maximum:     &maxStatus,
+				Minimum:     &minStatus,
+				Type:        "integer",
+			},
+			"title": {
+				Description: "A short, human-readable summary of the problem type.",
+				Type:        "string",
+			},
+			"type": {
+				Default:     problem.DefaultTypeURI,
+				Description: "A URI reference that identifies the problem type.",
+				Format:      "uri-reference",
+				Type:        "string",
+			},
+			"uuid": {
+				Description: "A UUID that identifies the specific occurrence of the problem.",
+				Format:      "uuid",
+				Type:        "string",
+			},
+		},
+		Required: []string{"status", "title", "type"},
+		Title:    "Problem Details",
+		Type:     "object",
+	}
+}
+
+// definitionSchema returns a Schema for the given entry that extends the ProblemDetails Schema with the type URI
+// reference and status of its definition, as well as its extension members.
+//
+// Any documentation of the definition (see problem.Definition.Documentation) is described using the schema description,
+// external documentation, and specification extensions so that it is never mistaken for a member of the problem.
+func definitionSchema(e entry, example *problem.Problem) *Schema {
+	props := map[string]*Schema{
+		"status": {Const: example.Status},
+		"type":   {Const: example.Type},
+	}
+	var required []string
+	for k, v := range e.def.Extensions {
+		props[k] = valueSchema(v)
+	}
+	for _, member := range e.members {
+		s := goTypeSchema(member.Type)
+		s.Description = member.Description
+		if s.Extensions == nil {
+			s.Extensions = make(map[string]any, 1)
+		}
+		s.Extensions[extensionGoType] = memberType(member)
+		props[member.Name] = s
+		required = append(required, member.Name)
+	}
+
+	s := &Schema{
+		AllOf: []*Schema{
+			{Ref: schemaRefPrefix + ProblemDetailsSchemaName},
+			{Properties: props, Required: required, Type: "object"},
+		},
+		Description: example.Detail,
+		Title:       example.Title,
+	}
+	docs := e.def.Documentation()
+	if docs.Description != "" {
+		s.Description = docs.Description
+	}
+	if len(docs.Links) > 0 {
+		s.ExternalDocs = &ExternalDocs{Description: docs.Links[0].Title, URL: docs.Links[0].URL}
+	}
+
+	ext := make(map[string]any, 6)
+	if e.def.Code != "" {
+		ext[extensionCode] = string(e.def.Code)
+	}
+	if len(docs.Links) > 1 {
+		ext[extensionLinks] = docs.Links
+	}
+	if level := e.def.Type.LogLevel; level != 0 {
+		ext[extensionLogLevel] = level.String()
+	}
+	if docs.Owner != "" {
+		ext[extensionOwner] = docs.Owner
+	}
+	if len(docs.Resolution) > 0 {
+		ext[extensionResolution] = docs.Resolution
+	}
+	if len(docs.Tags) > 0 {
+		ext[extensionTags] = docs.Tags
+	}
+	if len(ext) > 0 {
+		s.Extensions = ext
+	}
+	return s
+}
+
+// exampleBody returns the JSON representation of the given example problem.Problem as a map so that it can be
+// embedded within a MediaType.
+func exampleBody(example *problem.Problem) any {
+	b, err := json.Marshal(example)
+	if err != nil {
+		return nil
+	}
+	var m map[string]any
+	if err = json.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// exampleProblem returns an example problem.Problem generated from the definition of the given entry, where each
+// declared extension member is populated with an example value based on its Go type.
+func exampleProblem(gen *problem.Generator, e entry) *problem.Problem {
+	extensions := make(problem.Extensions, len(e.def.Extensions)+len(e.members))
+	maps.Copy(extensions, e.def.Extensions)
+	for _, member := range e.members {
+		if _, found := extensions[member.Name]; !found {
+			extensions[member.Name] = goTypeExample(member.Type)
+		}
+	}
+	return e.def.BuildContextUsing(context.Background(), gen).
+		Extensions(extensions).
+		Stack(problem.FlagDisable).
+		UUID(problem.FlagDisable).
+		Problem()
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package openapi provides support for generating OpenAPI 3.1 components from problem.Definition values, either
+// directly or from a catalog.Catalog, so that API specifications can be derived from the same definitions used to
+// generate problems rather than drifting from them.
+//
+// The generated Components contain a ProblemDetails schema describing all members emitted by the problem package along
+// with a schema and response for each definition, including an example body. For example;
+//
+//	components := openapi.FromCatalog(c)
+//	data, err := json.MarshalIndent(map[string]any{"components": components}, "", "  ")
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type (
+	// Components represents the subset of an OpenAPI Components Object containing the generated schemas and responses.
+	Components struct {
+		// Responses contains the Response for each definition, mapped to the name of the definition.
+		Responses map[string]*Response `json:"responses,omitempty" yaml:"responses,omitempty"`
+		// Schemas contains the ProblemDetails Schema along with the Schema for each definition, mapped to the name of
+		// the definition.
+		Schemas map[string]*Schema `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	}
+
+	// ExternalDocs represents an OpenAPI External Documentation Object.
+	ExternalDocs struct {
+		// Description is a description of the target documentation.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// URL is the URL of the target documentation.
+		URL string `json:"url" yaml:"url"`
+	}
+
+	// MediaType represents an OpenAPI Media Type Object.
+	MediaType struct {
+		// Example is an example of the media type.
+		Example any `json:"example,omitempty" yaml:"example,omitempty"`
+		// Schema is the Schema defining the content of the media type.
+		Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+	}
+
+	// Response represents an OpenAPI Response Object.
+	Response struct {
+		// Content contains the MediaType for each supported content type.
+		Content map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+		// Description is a description of the response.
+		Description string `json:"description" yaml:"description"`
+		// Extensions contains any specification extensions, whose keys must start with "x-".
+		Extensions map[string]any `json:"-" yaml:",inline"`
+	}
+
+	// Schema represents the subset of an OpenAPI 3.1 Schema Object (i.e. JSON Schema) used to describe problems.
+	Schema struct {
+		// AdditionalProperties is either a boolean or a Schema that controls any additional properties of an object.
+		AdditionalProperties any `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+		// AllOf contains schemas that must all be valid against the value.
+		AllOf []*Schema `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+		// Const is the only value that is valid.
+		Const any `json:"const,omitempty" yaml:"const,omitempty"`
+		// Default is the default value.
+		Default any `json:"default,omitempty" yaml:"default,omitempty"`
+		// Description is a description of the value.
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		// Extensions contains any specification extensions, whose keys must start with "x-".
+		Extensions map[string]any `json:"-" yaml:",inline"`
+		// ExternalDocs is additional external documentation for the value.
+		ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+		// Format is the format of the value (e.g. "uri-reference").
+		Format string `json:"format,omitempty" yaml:"format,omitempty"`
+		// Items is the Schema of each item within an array.
+		Items *Schema `json:"items,omitempty" yaml:"items,omitempty"`
+		// Maximum is the inclusive maximum of a number.
+		Maximum *int `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+		// Minimum is the inclusive minimum of a number.
+		Minimum *int `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+		// Properties contains the Schema for each property of an object.
+		Properties map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+		// Ref is a reference to another Schema (e.g. "#/components/schemas/ProblemDetails").
+		Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+		// Required contains the names of properties that are required.
+		Required []string `json:"required,omitempty" yaml:"required,omitempty"`
+		// Title is a short title of the value.
+		Title string `json:"title,omitempty" yaml:"title,omitempty"`
+		// Type is the JSON type of the value (e.g. "object").
+		Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	}
+
+	// jsonResponse is used to allow a Response to be marshaled without having Response.MarshalJSON invoked, resulting
+	// in a stack overflow.
+	jsonResponse Response
+
+	// jsonSchema is used to allow a Schema to be marshaled without having Schema.MarshalJSON invoked, resulting in a
+	// stack overflow.
+	jsonSchema Schema
+)
+
+// errExtensionKeyPrefix is returned if a specification extension key that does not start with extensionPrefix is
+// encountered.
+var errExtensionKeyPrefix = errors.New(`specification extension key must start with "x-"`)
+
+var (
+	_ json.Marshaler = (*Response)(nil)
+	_ json.Marshaler = (*Schema)(nil)
+)
+
+// MarshalJSON marshals the Response into JSON, including any Response.Extensions at the top level.
+func (r *Response) MarshalJSON() ([]byte, error) {
+	return marshalWithExtensions((*jsonResponse)(r), r.Extensions)
+}
+
+// MarshalJSON marshals the Schema into JSON, including any Schema.Extensions at the top level.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	return marshalWithExtensions((*jsonSchema)(s), s.Extensions)
+}
+
+// marshalWithExtensions marshals the given value, which must be marshaled into a JSON object, into JSON in a single
+// pass, appending the extensions provided at the top level in order of their keys.
+//
+// An error is returned if unable to marshal v or any extension or an extension key does not start with "x-", which
+// could otherwise conflict with the fields of v.
+func marshalWithExtensions(v any, extensions map[string]any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return b, err
+	}
+	for k := range extensions {
+		if !strings.HasPrefix(k, extensionPrefix) {
+			return nil, fmt.Errorf("%w: %q", errExtensionKeyPrefix, k)
+		}
+	}
+
+	// Drop the closing brace so that the extensions can be appended to the object
+	b = b[:len(b)-1]
+	for i, k := range slices.Sorted(maps.Keys(extensions)) {
+		if i > 0 || len(b) > 1 {
+			b = append(b, ',')
+		}
+		kb, _ := json.Marshal(k)
+		vb, err := json.Marshal(extensions[k])
+		if err != nil {
+			return nil, err
+		}
+		b = append(append(append(b, kb...), ':'), vb...)
+	}
+	return append(b, '}'), nil
+}
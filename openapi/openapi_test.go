@@ -0,0 +1,137 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/jay-babu/go-problem/catalog"
+	"github.com/jay-babu/go-problem/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromCatalog(t *testing.T) {
+	c, err := catalog.Loader{}.Load(catalog.File{
+		Definitions: map[string]catalog.DefinitionEntry{
+			"OutOfCredit": {
+				Definition: problem.Definition{
+					Code: "BILL-403",
+					Docs: problem.Documentation{
+						Description: "Returned when an account cannot cover the cost of a transaction.",
+						Owner:       "billing",
+						Tags:        []string{"billing"},
+					},
+					Extensions: map[string]any{"balance": 30},
+					Type: problem.Type{
+						Docs: problem.Documentation{
+							Links: []problem.Link{{Title: "Runbook", URL: "https://example.com/runbooks/out-of-credit"}},
+						},
+						LogLevel: problem.LogLevelWarn,
+						Status:   403,
+						Title:    "You do not have enough credit.",
+						URI:      "https://example.com/probs/out-of-credit",
+					},
+				},
+				Members: []catalog.Member{{Name: "accounts", Type: "[]string", Description: "Affected accounts"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	b, err := json.Marshal(openapi.FromCatalog(c))
+	require.NoError(t, err)
+
+	var actual map[string]any
+	require.NoError(t, json.Unmarshal(b, &actual))
+
+	var expected map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"allOf": [
+			{"$ref": "#/components/schemas/ProblemDetails"},
+			{
+				"properties": {
+					"accounts": {
+						"description": "Affected accounts",
+						"items": {"type": "string"},
+						"type": "array",
+						"x-go-type": "[]string"
+					},
+					"balance": {"type": "integer"},
+					"status": {"const": 403},
+					"type": {"const": "https://example.com/probs/out-of-credit"}
+				},
+				"required": ["accounts"],
+				"type": "object"
+			}
+		],
+		"description": "Returned when an account cannot cover the cost of a transaction.",
+		"externalDocs": {"description": "Runbook", "url": "https://example.com/runbooks/out-of-credit"},
+		"title": "You do not have enough credit.",
+		"x-problem-code": "BILL-403",
+		"x-problem-log-level": "WARN",
+		"x-problem-owner": "billing",
+		"x-problem-tags": ["billing"]
+	}`), &expected))
+
+	schemas := actual["schemas"].(map[string]any)
+	assert.Contains(t, schemas, openapi.ProblemDetailsSchemaName)
+	assert.Equal(t, expected, schemas["OutOfCredit"])
+
+	res := actual["responses"].(map[string]any)["OutOfCredit"].(map[string]any)
+	assert.Equal(t, "You do not have enough credit.", res["description"])
+	assert.Equal(t, "BILL-403", res["x-problem-code"])
+	content := res["content"].(map[string]any)[problem.ContentTypeJSON].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/OutOfCredit"}, content["schema"])
+	assert.Equal(t, map[string]any{
+		"accounts": []any{"string"},
+		"balance":  float64(30),
+		"code":     "BILL-403",
+		"status":   float64(403),
+		"title":    "You do not have enough credit.",
+		"type":     "https://example.com/probs/out-of-credit",
+	}, content["example"])
+}
+
+func TestSchema_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(&openapi.Schema{
+		Extensions: map[string]any{"x-b": []string{"<b>"}, "x-a": 1},
+		Title:      "Example",
+		Type:       "object",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"title":"Example","type":"object","x-a":1,"x-b":["\u003cb\u003e"]}`, string(b))
+
+	b, err = json.Marshal(&openapi.Schema{Extensions: map[string]any{"x-a": true}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"x-a":true}`, string(b))
+
+	b, err = json.Marshal(&openapi.Response{Description: "OK", Extensions: map[string]any{"x-a": nil}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"description":"OK","x-a":null}`, string(b))
+
+	_, err = json.Marshal(&openapi.Schema{Extensions: map[string]any{"type": "string"}})
+	assert.Error(t, err)
+	_, err = json.Marshal(&openapi.Schema{Extensions: map[string]any{"x-a": func() {}}})
+	assert.Error(t, err)
+}
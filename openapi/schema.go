@@ -0,0 +1,135 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package openapi
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jay-babu/go-problem/catalog"
+)
+
+// exampleTime is the time used as an example value of any time.Time extension member.
+var exampleTime = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// goTypeExample returns an example value for the given Go type expression.
+func goTypeExample(expr string) any {
+	expr = strings.TrimPrefix(strings.TrimSpace(expr), "*")
+	switch {
+	case expr == "string":
+		return "string"
+	case expr == "bool":
+		return true
+	case isGoInteger(expr), expr == "time.Duration":
+		return 0
+	case expr == "float32", expr == "float64":
+		return 0.0
+	case expr == "time.Time":
+		return exampleTime
+	case strings.HasPrefix(expr, "[]"):
+		return []any{goTypeExample(expr[2:])}
+	case strings.HasPrefix(expr, "map["):
+		return map[string]any{}
+	default:
+		return nil
+	}
+}
+
+// goTypeSchema returns a Schema describing the JSON representation of the given Go type expression, where possible.
+//
+// Only built-in types, slices, maps, pointers, time.Time, and time.Duration are understood. Any other type results in
+// an empty Schema, allowing any value.
+func goTypeSchema(expr string) *Schema {
+	expr = strings.TrimPrefix(strings.TrimSpace(expr), "*")
+	switch {
+	case expr == "string":
+		return &Schema{Type: "string"}
+	case expr == "bool":
+		return &Schema{Type: "boolean"}
+	case isGoInteger(expr), expr == "time.Duration":
+		return &Schema{Type: "integer"}
+	case expr == "float32", expr == "float64":
+		return &Schema{Type: "number"}
+	case expr == "time.Time":
+		return &Schema{Format: "date-time", Type: "string"}
+	case expr == "[]byte":
+		return &Schema{Format: "byte", Type: "string"}
+	case strings.HasPrefix(expr, "[]"):
+		return &Schema{Items: goTypeSchema(expr[2:]), Type: "array"}
+	case strings.HasPrefix(expr, "map[string]"):
+		return &Schema{AdditionalProperties: goTypeSchema(expr[len("map[string]"):]), Type: "object"}
+	default:
+		return &Schema{}
+	}
+}
+
+// isGoInteger returns whether the given Go type expression is a built-in integer type.
+func isGoInteger(expr string) bool {
+	switch expr {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte",
+		"rune":
+		return true
+	default:
+		return false
+	}
+}
+
+// memberType returns the Go type expression of the given catalog.Member.
+func memberType(member catalog.Member) string {
+	if member.Type == "" {
+		return "any"
+	}
+	return member.Type
+}
+
+// valueSchema returns a Schema inferred from the given value, which is typically the default value of an extension.
+func valueSchema(v any) *Schema {
+	switch _v := v.(type) {
+	case nil:
+		return &Schema{}
+	case string:
+		return &Schema{Type: "string"}
+	case bool:
+		return &Schema{Type: "boolean"}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return &Schema{Type: "integer"}
+	case float32, float64:
+		return &Schema{Type: "number"}
+	case time.Time:
+		return &Schema{Format: "date-time", Type: "string"}
+	case []any:
+		s := &Schema{Type: "array"}
+		if len(_v) > 0 {
+			s.Items = valueSchema(_v[0])
+		}
+		return s
+	case []string:
+		return &Schema{Items: &Schema{Type: "string"}, Type: "array"}
+	case map[string]any:
+		props := make(map[string]*Schema, len(_v))
+		for k, item := range _v {
+			props[k] = valueSchema(item)
+		}
+		return &Schema{Properties: props, Type: "object"}
+	default:
+		return &Schema{}
+	}
+}
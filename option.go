@@ -20,6 +20,11 @@
 
 package problem
 
+import (
+	"net/http"
+	"time"
+)
+
 // Option is used to customize the generation of a Problem and/or to override fields derived from a Definition and/or
 // Type.
 //
@@ -65,14 +70,19 @@ func WithCode(value uint, namespace CodeNamespace) Option {
 	}
 }
 
-// WithDetail customizes a Generator to return a Problem with the given detail. See Problem.Detail for more information.
+// WithDetail customizes a Generator to return a Problem with the given detail, optionally along with flags to be used to
+// control where the detail is visible. See Problem.Detail for more information.
 //
 // If detail is not empty, it will take precedence over anything provided using FromDefinition or any of the Wrap
 // options. However, if a localized detail is resolved from a translation key using WithDetailKey, that will take
 // precedence over detail.
-func WithDetail(detail string) Option {
+//
+// If no flags are provided, this is considered equal to passing FlagField and FlagLog. Passing only FlagLog results in
+// the detail being visible any time the Problem is logged (see LogInfo.Detail) without ever being present on
+// Problem.Detail. If FlagDisable is given, all other flags are ignored and the detail is not visible at all.
+func WithDetail(detail string, flags ...Flag) Option {
 	return func(b *Builder) {
-		b.Detail(detail)
+		b.Detail(detail, flags...)
 	}
 }
 
@@ -122,6 +132,19 @@ func WithExtension(key string, value any) Option {
 	}
 }
 
+// WithExtensionLogOnly customizes a Generator to return a Problem containing the given extension key and value, but only
+// to be visible any time the Problem is logged (see LogInfo.Extensions) without ever being present on
+// Problem.Extensions.
+//
+// If the key overlaps with any extension provided using WithExtension or WithExtensions, that extension is deleted.
+//
+// Panics if key is either empty or reserved (i.e. conflicts with Problem-level fields).
+func WithExtensionLogOnly(key string, value any) Option {
+	return func(b *Builder) {
+		b.ExtensionLogOnly(key, value)
+	}
+}
+
 // WithExtensions customizes a Generator to return a Problem with a shallow clone of the given extensions. See
 // Problem.Extensions for more information.
 //
@@ -139,6 +162,48 @@ func WithExtensions(extensions Extensions) Option {
 	}
 }
 
+// WithExtensionsFrom customizes a Generator to return a Problem with extensions derived from the given struct. See
+// Builder.ExtensionsFrom for more information.
+//
+// Like WithExtensions, any derived extensions will take precedence over anything provided using FromDefinition or any
+// of the Wrap options.
+//
+// Panics if v is of an unsupported type or results in a key that is either empty or reserved (i.e. conflicts with
+// Problem-level fields).
+func WithExtensionsFrom(v any) Option {
+	return func(b *Builder) {
+		b.ExtensionsFrom(v)
+	}
+}
+
+// WithHeader customizes a Generator to return a Problem with the given values appended to those of the HTTP response
+// header with the given key. See Problem.Header for more information.
+//
+// When used, the values of key will take precedence over those provided using FromDefinition or any of the Wrap
+// options.
+//
+// WithHeaders may be preferred for providing multiple headers and does not conflict with usage of WithHeader in that
+// neither option will delete/modify headers unless the key overlaps.
+func WithHeader(key string, values ...string) Option {
+	return func(b *Builder) {
+		b.Header(key, values...)
+	}
+}
+
+// WithHeaders customizes a Generator to return a Problem with a clone of the values of each of the given HTTP response
+// headers. See Problem.Header for more information.
+//
+// When used, the values of each key will take precedence over those provided using FromDefinition or any of the Wrap
+// options.
+//
+// WithHeader may be preferred for providing a single header and does not conflict with usage of WithHeaders in that
+// neither option will delete/modify headers unless the key overlaps, in which case the values will be overwritten.
+func WithHeaders(header http.Header) Option {
+	return func(b *Builder) {
+		b.Headers(header)
+	}
+}
+
 // WithInstance customizes a Generator to return a Problem with the given instance URI reference. See Problem.Instance
 // for more information.
 //
@@ -174,6 +239,45 @@ func WithLogLevel(level LogLevel) Option {
 	}
 }
 
+// WithRetryAfter customizes a Generator to return a Problem with the given retry delay. See Problem.RetryAfter for more
+// information.
+//
+// When the Problem is written to an HTTP response, the delay is included in the Retry-After header as a number of
+// seconds, rounded up. This option conflicts with WithRetryAt as only the last one used is applied.
+//
+// If delay is greater than zero, it will take precedence over anything provided using FromDefinition, FromType, or any
+// of the Wrap options.
+func WithRetryAfter(delay time.Duration) Option {
+	return func(b *Builder) {
+		b.RetryAfter(delay)
+	}
+}
+
+// WithRetryAt customizes a Generator to return a Problem with the given point in time after which a retry may be made.
+// See Problem.RetryAfter for more information.
+//
+// When the Problem is written to an HTTP response, the point in time is included in the Retry-After header as an
+// HTTP-date. This option conflicts with WithRetryAfter as only the last one used is applied.
+//
+// If t is not zero, it will take precedence over anything provided using FromDefinition, FromType, or any of the Wrap
+// options.
+func WithRetryAt(t time.Time) Option {
+	return func(b *Builder) {
+		b.RetryAt(t)
+	}
+}
+
+// WithRetryable customizes a Generator to return a Problem that is explicitly retryable, or not. See Problem.Retryable
+// for more information.
+//
+// When used, it will take precedence over anything provided using FromDefinition, FromType, or any of the Wrap
+// options, as well as any retry delay.
+func WithRetryable(retryable bool) Option {
+	return func(b *Builder) {
+		b.Retryable(retryable)
+	}
+}
+
 // WithStack customizes a Generator to control if/how a captured stack trace is visible on a Problem. See Problem.Stack
 // for more information.
 //
@@ -293,6 +397,18 @@ func WithUUID(flags ...Flag) Option {
 	}
 }
 
+// WithValidationErrors customizes a Generator to return a Problem containing the FieldError within the given
+// ValidationErrors. See ValidationErrors for more information.
+//
+// When used, the FieldError are included within an extension with the ValidationErrorsExtensionKey, which will take
+// precedence over any extension with the same key, with the detail of each FieldError being localized from its
+// translation key using Generator.Translator, where possible.
+func WithValidationErrors(errs *ValidationErrors) Option {
+	return func(b *Builder) {
+		b.ValidationErrors(errs)
+	}
+}
+
 // Wrap customizes a Generator to return a Problem wrapping the given error. See Problem.Error and Problem.Unwrap for
 // more information.
 //
@@ -304,8 +420,23 @@ func WithUUID(flags ...Flag) Option {
 //
 // If no Unwrapper is provided, Generator.Unwrapper is used from Builder.Generator if not nil, otherwise from
 // DefaultGenerator. If an Unwrapper could still not be resolved, it defaults to PropagatedFieldUnwrapper.
+//
+// If err wraps multiple errors itself and is not a Problem (e.g. the result of errors.Join), each of those errors is
+// treated as a separate cause (see Problem.Causes) and only the cause selected by Generator.CauseSelector is passed to
+// the Unwrapper.
 func Wrap(err error, unwrapper ...Unwrapper) Option {
 	return func(b *Builder) {
 		b.Wrap(err, unwrapper...)
 	}
 }
+
+// WrapAll customizes a Generator to return a Problem wrapping the given errors, each of which is treated as a separate
+// cause. See Problem.Causes, Problem.Error, and Problem.Unwrap for more information. Any nil errors are ignored.
+//
+// Like Wrap, Generator.Unwrapper is used to decide what, if any, information from a wrapped Problem is to be used when
+// building a Problem. However, only the cause selected by Generator.CauseSelector is passed to the Unwrapper.
+func WrapAll(errs ...error) Option {
+	return func(b *Builder) {
+		b.WrapAll(errs...)
+	}
+}
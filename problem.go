@@ -26,6 +26,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"maps"
+	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -37,35 +39,61 @@ type Extensions map[string]any
 
 var (
 	_ xml.Marshaler   = (Extensions)(nil)
-	_ xml.Unmarshaler = (Extensions)(nil)
+	_ xml.Unmarshaler = (*Extensions)(nil)
 )
 
 // MarshalXML marshals the encoded entries within the map into XML.
 //
 // This is required in order to allow extensions to be marshaled at the top-level of a Problem. Additionally,
-// xml.Unmarshaler does not support marshaling maps by default since XML documents are ordered by design. Unfortunately,
-// this function cannot guarantee ordering.
+// xml.Marshaler does not support marshaling maps by default since XML documents are ordered by design. To ensure that
+// the output is deterministic, entries are marshaled in order of their keys, as are those of any nested maps, while
+// slices and arrays are marshaled as a sequence of <i> elements in accordance with RFC 9457 Appendix B. For example;
+//
+//	<problem xmlns="urn:ietf:rfc:9457">
+//	  <!-- ... -->
+//	  <balance>30</balance>
+//	  <accounts>
+//	    <i>/account/12345</i>
+//	    <i>/account/67890</i>
+//	  </accounts>
+//	</problem>
 //
 // An error is returned if unable to marshal any of the entries or the map contains a key that is either empty or
 // reserved (i.e. conflicts with Problem-level fields).
 func (es Extensions) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
-	for k, v := range es {
-		err := validationExtensionKey(k)
-		if err != nil {
+	keys := slices.Sorted(maps.Keys(es))
+	for _, k := range keys {
+		if err := validationExtensionKey(k); err != nil {
 			return err
 		}
-		err = e.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: k}})
-		if err != nil {
+	}
+	for _, k := range keys {
+		if err := encodeXMLValue(e, k, es[k]); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// UnmarshalXML does nothing but is required to ensure that any <extensions> element is not unmarshaled as this cannot
-// be supported.
-func (es Extensions) UnmarshalXML(_ *xml.Decoder, _ xml.StartElement) error {
-	// Does nothing
+// UnmarshalXML unmarshals each child element of the given xml.StartElement into an entry within the map.
+//
+// Since XML carries no type information, each element is unmarshaled into a string if it contains no child elements, a
+// slice if all of its child elements are <i> elements (in accordance with RFC 9457 Appendix B), and a map otherwise.
+// Elements that are repeated are collected into a slice. Namespaces are ignored.
+//
+// An error is returned if unable to unmarshal the XML.
+func (es *Extensions) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	_, children, err := decodeXMLChildren(d)
+	if err != nil {
+		return err
+	}
+	if len(children) == 0 {
+		return nil
+	}
+	if *es == nil {
+		*es = make(Extensions, len(children))
+	}
+	maps.Copy(*es, groupXMLChildren(children))
 	return nil
 }
 
@@ -98,11 +126,12 @@ type (
 		// Clients consuming problem details MUST ignore any such extensions that they don't recognize; this allows
 		// problem types to evolve and include additional information in the future.
 		//
-		// If/when the Problem is marshalled to JSON or XML any such extensions are serialized at the top level.
-		// However, such information is lost if an attempt is made to unmarshal the serialized data bac into XML due to
-		// limitations with xml.Unmarshaler. JSON data can be unmarshaled without any issues. If Extensions contains a
-		// key that is empty or reserved (i.e. conflicts with Problem-level fields), an error will occur when attempting
-		// to marshal the Problem to JSON or XML.
+		// If/when the Problem is marshalled to JSON or XML any such extensions are serialized at the top level and,
+		// likewise, any unrecognized top-level properties/elements are unmarshaled into Extensions. As XML carries no
+		// type information, values unmarshaled from XML are limited to strings, slices, and maps (see
+		// Extensions.UnmarshalXML for more information). If Extensions contains a key that is empty or reserved (i.e.
+		// conflicts with Problem-level fields), an error will occur when attempting to marshal the Problem to JSON or
+		// XML.
 		Extensions Extensions `json:"-" xml:"extensions,omitempty"`
 		// Instance is a URI reference that identifies the specific occurrence of the Problem.
 		//
@@ -157,15 +186,25 @@ type (
 		// contain a generated "UUID" internally for logging within LogValue, however, UUID will be empty. This can be
 		// useful for cases where a "UUID" is desired for logging only.
 		UUID string `json:"uuid,omitempty" xml:"uuid,omitempty"`
-		// err is the error wrapped within the Problem, where applicable.
-		err error
+		// definition is the Definition from which the Problem was generated or, if decoded, the Definition registered
+		// against its type URI reference, where applicable.
+		definition *Definition
+		// errs contains the errors wrapped within the Problem, where applicable.
+		errs []error
+		// header contains the HTTP response headers to be written along with the Problem, which are never serialized.
+		header http.Header
+		// keys contains the translation keys from which Title and Detail were resolved, if any, allowing them to be
+		// localized again later (e.g. in the language negotiated when writing the Problem to an HTTP response).
+		keys translationKeys
 		// logInfo contains the relevant logging information for the Problem.
 		logInfo LogInfo
+		// retry contains the information describing whether, and when, the request that resulted in the Problem may be
+		// retried.
+		retry retryInfo
+		// value is the value into which the Problem was decoded using a Go type registered against its type URI
+		// reference, where applicable.
+		value any
 	}
-
-	// jsonProblem is used to allow JSON data to be unmarshaled into a Problem struct without having
-	// Problem.UnmarshalJSON invoked, resulting in a stack overflow.
-	jsonProblem Problem
 )
 
 const (
@@ -194,6 +233,7 @@ var (
 	_ json.Marshaler   = (*Problem)(nil)
 	_ json.Unmarshaler = (*Problem)(nil)
 	_ xml.Marshaler    = (*Problem)(nil)
+	_ xml.Unmarshaler  = (*Problem)(nil)
 )
 
 // reservedExtensions contains extension keys that are reserved. These are typically the names of serialized fields on a
@@ -211,6 +251,20 @@ var reservedExtensions = map[string]struct{}{
 	"uuid":       {},
 }
 
+// Causes returns the errors wrapped by the Problem, if any, where any error that wraps multiple errors itself and is not
+// a Problem (e.g. the result of errors.Join) is replaced by those errors. Otherwise, nil is returned.
+//
+// For example;
+//
+//	prob := problem.New(problem.Wrap(errors.Join(err1, err2)))
+//	prob.Causes()  // []error{err1, err2}
+func (p *Problem) Causes() []error {
+	if p == nil {
+		return nil
+	}
+	return flattenCauses(p.errs)
+}
+
 // Clone returns a deep copy of the Problem.
 func (p *Problem) Clone() *Problem {
 	if p == nil {
@@ -219,9 +273,48 @@ func (p *Problem) Clone() *Problem {
 
 	c := *p
 	c.Extensions = maps.Clone(p.Extensions)
+	c.logInfo.Extensions = maps.Clone(p.logInfo.Extensions)
+	c.errs = slices.Clone(p.errs)
+	c.header = p.header.Clone()
 	return &c
 }
 
+// DecodeExtensions decodes the extensions within the Problem into the value pointed to by dst, typically a pointer to a
+// struct, by marshaling them into JSON and unmarshaling them into dst. As such, the "json" tags of any struct fields are
+// honored. For example;
+//
+//	var conflict struct {
+//		ResourceIDs []string `json:"resourceIds"`
+//	}
+//	err := prob.DecodeExtensions(&conflict)
+//
+// Nothing is decoded if the Problem has no extensions. ExtensionAs may be preferred for obtaining the value of a single
+// extension.
+//
+// An error is returned if unable to marshal the extensions or unmarshal them into dst.
+func (p *Problem) DecodeExtensions(dst any) error {
+	if p == nil || len(p.Extensions) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(map[string]any(p.Extensions))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// Definition returns the Definition from which the Problem was generated, if any.
+//
+// If the Problem was instead decoded (e.g. via Problem.UnmarshalJSON, Problem.UnmarshalXML, or ReadResponse), the
+// Definition registered within the Registry of the relevant Generator against its type URI reference is returned, if
+// any. See Registry for more information.
+func (p *Problem) Definition() (Definition, bool) {
+	if p == nil || p.definition == nil {
+		return Definition{}, false
+	}
+	return *p.definition, true
+}
+
 // Error returns the most suitable error message for the Problem.
 //
 // If the Problem wraps another error, the message of that error will be included.
@@ -237,33 +330,28 @@ func (p *Problem) Extension(key string) (value any, found bool) {
 	return
 }
 
+// Header returns a clone of the HTTP response headers to be written along with the Problem, if any.
+//
+// Headers are never serialized as part of the Problem itself, however, they are written to the HTTP response when using
+// functions like WriteProblem, where any headers provided by WriteOptions.Header take precedence. See Builder.Header
+// for more information.
+func (p *Problem) Header() http.Header {
+	if p == nil {
+		return nil
+	}
+	return p.header.Clone()
+}
+
 // MarshalJSON marshals the Problem into JSON.
 //
-// This is required in order to allow Problem.Extensions to be marshaled at the top-level of a Problem. Unfortunately,
-// this can only be managed by marshaling the problem details twice so is suboptimal in terms of performance.
+// This is required in order to allow Problem.Extensions to be marshaled at the top-level of a Problem. The Problem is
+// marshaled in a single pass with all members, including extensions, written in order of their names so that the output
+// is deterministic.
 //
 // An error is returned if unable to marshal the Problem or Problem.Extensions contains a key that is either empty or
 // reserved (i.e. conflicts with Problem-level fields).
 func (p *Problem) MarshalJSON() ([]byte, error) {
-	b, err := json.Marshal(*p)
-	if err != nil {
-		return nil, err
-	}
-	if len(p.Extensions) == 0 {
-		return b, nil
-	}
-	var m map[string]any
-	if err = json.Unmarshal(b, &m); err != nil {
-		return nil, err
-	}
-	for k, v := range p.Extensions {
-		err = validationExtensionKey(k)
-		if err != nil {
-			return nil, err
-		}
-		m[k] = v
-	}
-	return json.Marshal(m)
+	return appendJSONProblem(make([]byte, 0, 256), p)
 }
 
 // MarshalXML marshals the Problem into XML.
@@ -290,37 +378,61 @@ func (p *Problem) String() string {
 
 // UnmarshalJSON unmarshals the JSON data provided into the Problem.
 //
-// This is required in order to unmarshal any superfluous JSON properties at the top-level into Problem.Extensions.
-// Unfortunately, this can only be managed by unmarshaling the JSON twice so is suboptimal in terms of performance.
+// This is required in order to unmarshal any superfluous JSON properties at the top-level into Problem.Extensions. The
+// JSON is unmarshaled in a single pass, with reserved properties only being unmarshaled into their corresponding fields
+// when their names match exactly.
+//
+// If DefaultGenerator has a Registry, the Problem is linked to any Definition and decoded into any Go type registered
+// against its type URI reference. See Registry for more information. Since no other Generator can be passed, the
+// Registry of any other Generator is never used, so Generator.DecodeJSON should be used instead in such cases.
 //
 // An error is returned if unable to unmarshal data.
 func (p *Problem) UnmarshalJSON(data []byte) error {
-	var jp jsonProblem
-	if err := json.Unmarshal(data, &jp); err != nil {
+	if err := decodeJSONProblem(data, p); err != nil {
 		return err
 	}
-	var m map[string]any
-	if err := json.Unmarshal(data, &m); err != nil {
+	DefaultGenerator.Registry.resolve(p, data)
+	return nil
+}
+
+// UnmarshalXML unmarshals the XML element provided into the Problem.
+//
+// This is required in order to unmarshal any superfluous XML elements at the top-level into Problem.Extensions, in the
+// same way as Extensions.UnmarshalXML, allowing a Problem marshaled into XML to be unmarshaled back again. Namespaces
+// are ignored so both the RFC 9457 namespace (i.e. "urn:ietf:rfc:9457") and any other (or none) are supported.
+//
+// If DefaultGenerator has a Registry, the Problem is linked to any Definition and decoded into any Go type registered
+// against its type URI reference. See Registry for more information. Since no other Generator can be passed, the
+// Registry of any other Generator is never used, so Generator.DecodeXML should be used instead in such cases.
+//
+// An error is returned if unable to unmarshal the XML or if any top-level field contains an invalid value.
+func (p *Problem) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	if err := decodeXMLProblem(d, p); err != nil {
 		return err
 	}
-	for k := range m {
-		if _, reserved := reservedExtensions[k]; reserved {
-			delete(m, k)
-		}
-	}
-	if len(m) > 0 {
-		jp.Extensions = m
-	}
-	*p = Problem(jp)
+	DefaultGenerator.Registry.resolve(p, nil)
 	return nil
 }
 
-// Unwrap returns the error wrapped by the Problem, if any, otherwise returns nil.
-func (p *Problem) Unwrap() error {
+// Unwrap returns the errors wrapped by the Problem, if any, otherwise returns nil. This allows functions like errors.Is
+// and errors.As to check the tree of each wrapped error.
+//
+// The returned slice must not be modified. Problem.Causes may be preferred to obtain the individual causes of the
+// Problem.
+func (p *Problem) Unwrap() []error {
 	if p == nil {
 		return nil
 	}
-	return p.err
+	return p.errs
+}
+
+// Value returns the value into which the Problem was decoded using the Go type registered within the Registry of the
+// relevant Generator against its type URI reference, if any. See RegisterValue and ValueAs for more information.
+func (p *Problem) Value() any {
+	if p == nil {
+		return nil
+	}
+	return p.value
 }
 
 // buildString returns a string representation of the Problem while providing control over whether any wrapped error is
@@ -344,9 +456,15 @@ func (p *Problem) buildString(inclErr bool) string {
 		sb.WriteString(string(p.Code))
 		sb.WriteRune(']')
 	}
-	if inclErr && p.err != nil {
-		sb.WriteString(": ")
-		sb.WriteString(p.err.Error())
+	if inclErr {
+		for i, cause := range p.Causes() {
+			if i == 0 {
+				sb.WriteString(": ")
+			} else {
+				sb.WriteString("; ")
+			}
+			sb.WriteString(cause.Error())
+		}
 	}
 	return sb.String()
 }
@@ -0,0 +1,408 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+type (
+	// ProblemSet contains the results of processing multiple items within a single request (e.g. a bulk API), where each
+	// item has either succeeded or resulted in a Problem, allowing a single response document to be written for all of
+	// them using Generator.WriteProblemSet.
+	//
+	// For example;
+	//
+	//	var set problem.ProblemSet
+	//	for i, item := range items {
+	//		if err := create(ctx, item); err != nil {
+	//			set.FailAt(i, problem.NewContext(ctx, problem.Wrap(err)))
+	//		} else {
+	//			set.SucceedAt(i, http.StatusCreated)
+	//		}
+	//	}
+	//	problem.WriteProblemSet(&set, w, req)
+	//
+	// When marshaled, a ProblemSet contains its overall status (see ProblemSet.Status) and the result of each item in the
+	// order in which they were added. For example, in JSON;
+	//
+	//	{
+	//		"status": 207,
+	//		"results": [
+	//			{"id": "0", "status": 201},
+	//			{"id": "1", "status": 409, "problem": {"status": 409, "title": "Conflict"}}
+	//		]
+	//	}
+	//
+	// And in XML, where each result is represented as an array item in accordance with RFC 9457 Appendix B;
+	//
+	//	<problems xmlns="urn:ietf:rfc:9457">
+	//		<status>207</status>
+	//		<results>
+	//			<i><id>0</id><status>201</status></i>
+	//			<i><id>1</id><status>409</status><problem><status>409</status><title>Conflict</title></problem></i>
+	//		</results>
+	//	</problems>
+	//
+	// The zero value is ready to use. A ProblemSet is not safe for concurrent use.
+	ProblemSet struct {
+		// results contains the result of each item in the order in which they were added.
+		results []SetResult
+	}
+
+	// SetResult represents the result of processing a single item within a ProblemSet.
+	SetResult struct {
+		// ID is the identifier of the item, typically either its index within the request or an ID that uniquely
+		// identifies it.
+		ID string `json:"id" xml:"id"`
+		// Problem is the Problem that occurred while processing the item, if any. If nil, the item succeeded.
+		Problem *Problem `json:"problem,omitempty" xml:"problem,omitempty"`
+		// Status is the status code of the item, which is the status of Problem, if any.
+		Status int `json:"status" xml:"status"`
+	}
+
+	// problemSetDocument is the representation of a ProblemSet when marshaled and unmarshaled.
+	problemSetDocument struct {
+		XMLName xml.Name    `json:"-" xml:"urn:ietf:rfc:9457 problems"`
+		Status  int         `json:"status" xml:"status"`
+		Results []SetResult `json:"results" xml:"results>i"`
+	}
+)
+
+const (
+	// xmlDefaultSetLocalName is used to detect whenever a ProblemSet is being marshaled to XML without an explicit local
+	// name so that it can be replaced with a preferred one.
+	xmlDefaultSetLocalName = "ProblemSet"
+	// xmlPreferredSetLocalName is substituted for xmlDefaultSetLocalName whenever it is detected while a ProblemSet is
+	// being marshaled to XML.
+	xmlPreferredSetLocalName = "problems"
+)
+
+// problemSetType is the Type of the Problem used to summarize the items within a ProblemSet that resulted in a Problem
+// when they are logged or wrapped by a failed content negotiation.
+var problemSetType = Type{
+	LogLevel: LogLevelWarn,
+	Status:   http.StatusMultiStatus,
+	Title:    http.StatusText(http.StatusMultiStatus),
+}
+
+// Fail records that the item with the given ID resulted in the Problem provided, using Problem.Status as the status of
+// the item with a fallback to http.StatusInternalServerError.
+//
+// If prob is nil, the item is recorded as having succeeded with http.StatusOK.
+func (s *ProblemSet) Fail(id string, prob *Problem) {
+	if prob == nil {
+		s.Succeed(id, http.StatusOK)
+		return
+	}
+	s.results = append(s.results, SetResult{
+		ID:      id,
+		Problem: prob,
+		Status:  firstNonZeroValue(prob.Status, http.StatusInternalServerError),
+	})
+}
+
+// FailAt is a convenient shorthand for calling ProblemSet.Fail with the string representation of the given index.
+func (s *ProblemSet) FailAt(index int, prob *Problem) {
+	s.Fail(strconv.Itoa(index), prob)
+}
+
+// Failed returns the number of items within the ProblemSet that resulted in a Problem.
+func (s *ProblemSet) Failed() int {
+	var n int
+	for _, r := range s.results {
+		if r.Problem != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Len returns the number of items within the ProblemSet.
+func (s *ProblemSet) Len() int {
+	return len(s.results)
+}
+
+// MarshalJSON marshals the ProblemSet into JSON.
+//
+// An error is returned if unable to marshal any Problem within the ProblemSet.
+func (s *ProblemSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.document())
+}
+
+// MarshalXML marshals the ProblemSet into XML.
+//
+// Like Problem.MarshalXML, the local and space names on the xml.StartElement are only replaced when their default
+// values are expected, in which case local and space names that are consistent with those used for a Problem are
+// preferred.
+//
+// An error is returned if unable to marshal any Problem within the ProblemSet.
+func (s *ProblemSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if start.Name.Local == xmlDefaultSetLocalName {
+		start.Name.Local = xmlPreferredSetLocalName
+	}
+	if start.Name.Space == xmlDefaultSpaceName {
+		start.Name.Space = xmlPreferredSpaceName
+	}
+	return e.EncodeElement(s.document(), start)
+}
+
+// Results returns a copy of the result of each item within the ProblemSet in the order in which they were added.
+func (s *ProblemSet) Results() []SetResult {
+	return slices.Clone(s.results)
+}
+
+// Status returns the overall status code derived from the items within the ProblemSet.
+//
+// If all items share the same status code, that status code is returned (e.g. http.StatusCreated if every item was
+// created or http.StatusBadRequest if every item was invalid). Otherwise, http.StatusMultiStatus is returned. If the
+// ProblemSet is empty, http.StatusOK is returned.
+func (s *ProblemSet) Status() int {
+	if len(s.results) == 0 {
+		return http.StatusOK
+	}
+	status := s.results[0].Status
+	for _, r := range s.results[1:] {
+		if r.Status != status {
+			return http.StatusMultiStatus
+		}
+	}
+	return status
+}
+
+// Succeed records that the item with the given ID succeeded with the status code provided. If status is less than or
+// equal to zero, http.StatusOK is used.
+func (s *ProblemSet) Succeed(id string, status int) {
+	if status <= 0 {
+		status = http.StatusOK
+	}
+	s.results = append(s.results, SetResult{ID: id, Status: status})
+}
+
+// SucceedAt is a convenient shorthand for calling ProblemSet.Succeed with the string representation of the given index.
+func (s *ProblemSet) SucceedAt(index int, status int) {
+	s.Succeed(strconv.Itoa(index), status)
+}
+
+// UnmarshalJSON unmarshals the JSON data provided into the ProblemSet, replacing any existing results.
+//
+// An error is returned if unable to unmarshal data.
+func (s *ProblemSet) UnmarshalJSON(data []byte) error {
+	var doc problemSetDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	s.results = doc.Results
+	return nil
+}
+
+// UnmarshalXML unmarshals the XML element provided into the ProblemSet, replacing any existing results. Namespaces are
+// ignored in the same way as Problem.UnmarshalXML.
+//
+// An error is returned if unable to unmarshal the XML element.
+func (s *ProblemSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var doc struct {
+		Results []SetResult `xml:"results>i"`
+	}
+	if err := d.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+	s.results = doc.Results
+	return nil
+}
+
+// document returns the representation of the ProblemSet to be marshaled.
+func (s *ProblemSet) document() problemSetDocument {
+	results := s.results
+	if results == nil {
+		results = []SetResult{}
+	}
+	return problemSetDocument{Status: s.Status(), Results: results}
+}
+
+// logProblem returns a Problem summarizing the items within the ProblemSet that resulted in a Problem, wrapping each of
+// them, so that they can be logged together via Generator.LogContext.
+//
+// The Problem is built from problemSetType using Generator, with its status and title reflecting ProblemSet.Status and
+// its LogLevel being the most severe of those wrapped.
+func (s *ProblemSet) logProblem(ctx context.Context, gen *Generator) *Problem {
+	var (
+		errs  []error
+		ids   []string
+		level LogLevel
+	)
+	for _, r := range s.results {
+		if r.Problem != nil {
+			errs = append(errs, r.Problem)
+			ids = append(ids, r.ID)
+			level = max(level, r.Problem.logLevel())
+		}
+	}
+	status := s.Status()
+	return gen.BuildContext(ctx).
+		DefinitionType(problemSetType).
+		Status(status).
+		Title(http.StatusText(status)).
+		Detailf("%d of %d items failed", len(errs), len(s.results)).
+		Extension("failed", ids).
+		LogLevel(level).
+		Stack(FlagDisable).
+		UUID(FlagDisable).
+		wrap(errs, []Unwrapper{NoopUnwrapper()}).
+		Problem()
+}
+
+// transform returns a copy of the ProblemSet where each Problem has been replaced with the result of the function
+// provided.
+func (s *ProblemSet) transform(fn func(prob *Problem) *Problem) *ProblemSet {
+	results := slices.Clone(s.results)
+	for i, r := range results {
+		if r.Problem != nil {
+			results[i].Problem = fn(r.Problem)
+		}
+	}
+	return &ProblemSet{results: results}
+}
+
+// WriteProblemSet writes a single HTTP response for the given ProblemSet, relying on WriteOptions.ContentType to
+// determine how the response is formed, with a graceful fallback to a content/media type negotiated using the Accept
+// header of req (see Generator.Negotiation), Generator.ContentType, and ContentTypeJSONUTF8. WriteOptions can also be
+// passed for more granular control.
+//
+// Since a ProblemSet is not itself a problem, the generic equivalent of the content/media type is used (e.g.
+// ContentTypeGenericJSONUTF8 instead of ContentTypeJSONUTF8) and ContentTypeGenericJSONUTF8 is used in place of
+// ContentTypeHTML. The status code of the response is ProblemSet.Status unless WriteOptions.Status is provided and only
+// the headers within WriteOptions.Header are written.
+//
+// If any items resulted in a Problem, they are logged together via a single call to Generator.LogContext, passing a
+// Problem that wraps each of them and summarizes the ProblemSet, rather than one call per item. Each Problem is
+// localized in the language negotiated for the response, if any (see Generator.Languages), and redacted using
+// Generator.Redactor, if any, after it has been logged.
+//
+// An error is returned if set fails to be written to w.
+func (g *Generator) WriteProblemSet(set *ProblemSet, w http.ResponseWriter, req *http.Request, opts ...WriteOptions) error {
+	_opts, acceptable := g.negotiateWriteOptions(w, req, WriteOptions{ContentType: g.contentType()}, opts)
+	if !acceptable {
+		return g.writeNotAcceptable(set.logProblem(req.Context(), g), w, req, _opts)
+	}
+	if isValidContentTypeForXML(_opts.ContentType) {
+		return g.writeProblemSetXML(set, w, req, _opts)
+	}
+	return g.writeProblemSetJSON(set, w, req, _opts)
+}
+
+// WriteProblemSetJSON writes a single HTTP response for the given ProblemSet in JSON format, optionally using
+// WriteOptions for more granular control. See Generator.WriteProblemSet for more information.
+//
+// An error is returned if set fails to be written to w.
+func (g *Generator) WriteProblemSetJSON(set *ProblemSet, w http.ResponseWriter, req *http.Request, opts ...WriteOptions) error {
+	return g.writeProblemSetJSON(set, w, req, WriteOptions{ContentType: ContentTypeGenericJSONUTF8}.apply(opts, isValidContentTypeForJSON))
+}
+
+// WriteProblemSetXML writes a single HTTP response for the given ProblemSet in XML format, optionally using
+// WriteOptions for more granular control. See Generator.WriteProblemSet for more information.
+//
+// An error is returned if set fails to be written to w.
+func (g *Generator) WriteProblemSetXML(set *ProblemSet, w http.ResponseWriter, req *http.Request, opts ...WriteOptions) error {
+	return g.writeProblemSetXML(set, w, req, WriteOptions{ContentType: ContentTypeGenericXMLUTF8}.apply(opts, isValidContentTypeForXML))
+}
+
+// prepareProblemSet logs the items within the given ProblemSet that resulted in a Problem, if any, using WriteOptions,
+// that are expected to have been applied, before returning a copy of the ProblemSet that has been localized in the
+// language negotiated for the response, if any, and redacted. Where WriteOptions.Status overrides the status code of
+// the response, each Problem is redacted as if it were written with that status code.
+func (g *Generator) prepareProblemSet(set *ProblemSet, w http.ResponseWriter, req *http.Request, opts WriteOptions) *ProblemSet {
+	ctx := g.languageContext(w, req)
+	if !opts.LogDisabled && opts.LogMessage != "" && set.Failed() > 0 {
+		logSet := set.transform(func(prob *Problem) *Problem {
+			return g.localizeForLog(req.Context(), prob)
+		})
+		g.LogContext(req.Context(), opts.LogMessage, logSet.logProblem(req.Context(), g), opts.LogArgs...)
+	}
+	return set.transform(func(prob *Problem) *Problem {
+		return g.redactResponse(ctx, g.localizeResponse(ctx, w, prob), opts.Status)
+	})
+}
+
+// writeProblemSetJSON writes an HTTP response for the given ProblemSet in JSON format using WriteOptions, that are
+// expected to have been applied, to determine how the response is formed and whether the ProblemSet is logged.
+//
+// An error is returned if set fails to be written to w.
+func (g *Generator) writeProblemSetJSON(set *ProblemSet, w http.ResponseWriter, req *http.Request, opts WriteOptions) error {
+	set = g.prepareProblemSet(set, w, req, opts)
+	if opts.ContentType != ContentTypeGenericJSON {
+		opts.ContentType = ContentTypeGenericJSONUTF8
+	}
+
+	writeSetHeader(w, set, opts)
+
+	return json.NewEncoder(w).Encode(set)
+}
+
+// writeProblemSetXML writes an HTTP response for the given ProblemSet in XML format using WriteOptions, that are
+// expected to have been applied, to determine how the response is formed and whether the ProblemSet is logged.
+//
+// An error is returned if set fails to be written to w.
+func (g *Generator) writeProblemSetXML(set *ProblemSet, w http.ResponseWriter, req *http.Request, opts WriteOptions) error {
+	set = g.prepareProblemSet(set, w, req, opts)
+	if opts.ContentType != ContentTypeGenericXML {
+		opts.ContentType = ContentTypeGenericXMLUTF8
+	}
+
+	writeSetHeader(w, set, opts)
+
+	return xml.NewEncoder(w).Encode(set)
+}
+
+// writeSetHeader writes the header and status code of an HTTP response for the given ProblemSet using WriteOptions,
+// that are expected to have been applied.
+//
+// Only the headers within WriteOptions.Header are written, with the Content-Type header always being written last so
+// that it cannot be overridden.
+func writeSetHeader(w http.ResponseWriter, set *ProblemSet, opts WriteOptions) {
+	header := w.Header()
+	mergeHeader(header, opts.Header)
+	header.Set(contentTypeHeader, opts.ContentType)
+	w.WriteHeader(firstNonZeroValue(opts.Status, set.Status()))
+}
+
+// WriteProblemSet is a convenient shorthand for calling Generator.WriteProblemSet on the Generator within the given
+// HTTP request's context.Context, if any, otherwise DefaultGenerator.
+func WriteProblemSet(set *ProblemSet, w http.ResponseWriter, req *http.Request, opts ...WriteOptions) error {
+	return GetGenerator(req.Context()).WriteProblemSet(set, w, req, opts...)
+}
+
+// WriteProblemSetJSON is a convenient shorthand for calling Generator.WriteProblemSetJSON on the Generator within the
+// given HTTP request's context.Context, if any, otherwise DefaultGenerator.
+func WriteProblemSetJSON(set *ProblemSet, w http.ResponseWriter, req *http.Request, opts ...WriteOptions) error {
+	return GetGenerator(req.Context()).WriteProblemSetJSON(set, w, req, opts...)
+}
+
+// WriteProblemSetXML is a convenient shorthand for calling Generator.WriteProblemSetXML on the Generator within the
+// given HTTP request's context.Context, if any, otherwise DefaultGenerator.
+func WriteProblemSetXML(set *ProblemSet, w http.ResponseWriter, req *http.Request, opts ...WriteOptions) error {
+	return GetGenerator(req.Context()).WriteProblemSetXML(set, w, req, opts...)
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblemSet_Status(t *testing.T) {
+	var set problem.ProblemSet
+	assert.Equal(t, http.StatusOK, set.Status())
+
+	set.SucceedAt(0, http.StatusCreated)
+	set.Succeed("b", 0)
+	assert.Equal(t, http.StatusMultiStatus, set.Status())
+
+	var failed problem.ProblemSet
+	failed.FailAt(0, problem.New(problem.WithStatus(http.StatusBadRequest)))
+	failed.FailAt(1, problem.New(problem.WithStatus(http.StatusBadRequest)))
+	assert.Equal(t, http.StatusBadRequest, failed.Status())
+	assert.Equal(t, 2, failed.Failed())
+
+	failed.Fail("c", nil)
+	assert.Equal(t, http.StatusMultiStatus, failed.Status())
+	assert.Equal(t, 2, failed.Failed())
+	assert.Equal(t, 3, failed.Len())
+}
+
+func TestProblemSet_MarshalJSON(t *testing.T) {
+	var set problem.ProblemSet
+	set.SucceedAt(0, http.StatusCreated)
+	set.FailAt(1, problem.New(problem.WithStatus(http.StatusConflict), problem.WithTitle("Conflict")))
+
+	b, err := json.Marshal(&set)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"status": 207,
+		"results": [
+			{"id": "0", "status": 201},
+			{"id": "1", "status": 409, "problem": {"status": 409, "title": "Conflict", "type": "about:blank"}}
+		]
+	}`, string(b))
+
+	var decoded problem.ProblemSet
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	results := decoded.Results()
+	require.Len(t, results, 2)
+	assert.Nil(t, results[0].Problem)
+	require.NotNil(t, results[1].Problem)
+	assert.Equal(t, "Conflict", results[1].Problem.Title)
+	assert.Equal(t, http.StatusMultiStatus, decoded.Status())
+}
+
+func TestProblemSet_MarshalXML(t *testing.T) {
+	var set problem.ProblemSet
+	set.SucceedAt(0, http.StatusCreated)
+	set.FailAt(1, problem.New(problem.WithStatus(http.StatusConflict), problem.WithTitle("Conflict")))
+
+	b, err := xml.Marshal(&set)
+	require.NoError(t, err)
+	assert.Equal(t, `<problems xmlns="urn:ietf:rfc:9457"><status>207</status><results>`+
+		`<i><id>0</id><status>201</status></i>`+
+		`<i><id>1</id><problem xmlns="urn:ietf:rfc:9457"><status>409</status><title>Conflict</title><type>about:blank</type></problem><status>409</status></i>`+
+		`</results></problems>`, string(b))
+
+	var decoded problem.ProblemSet
+	require.NoError(t, xml.Unmarshal(b, &decoded))
+	results := decoded.Results()
+	require.Len(t, results, 2)
+	assert.Equal(t, "0", results[0].ID)
+	assert.Nil(t, results[0].Problem)
+	require.NotNil(t, results[1].Problem)
+	assert.Equal(t, http.StatusConflict, results[1].Problem.Status)
+}
+
+func TestProblemSet_MarshalXML_StartName(t *testing.T) {
+	var set problem.ProblemSet
+	set.SucceedAt(0, http.StatusCreated)
+
+	b, err := xml.Marshal(struct {
+		XMLName xml.Name            `xml:"response"`
+		Set     *problem.ProblemSet `xml:"bulk"`
+	}{Set: &set})
+	require.NoError(t, err)
+	assert.Equal(t, `<response><bulk xmlns="urn:ietf:rfc:9457"><status>201</status><results>`+
+		`<i><id>0</id><status>201</status></i></results></bulk></response>`, string(b))
+
+	b, err = xml.Marshal(struct {
+		XMLName xml.Name            `xml:"response"`
+		Set     *problem.ProblemSet `xml:"urn:example bulk"`
+	}{Set: &set})
+	require.NoError(t, err)
+	assert.Equal(t, `<response><bulk xmlns="urn:example"><status>201</status><results>`+
+		`<i><id>0</id><status>201</status></i></results></bulk></response>`, string(b))
+}
+
+func TestWriteProblemSet(t *testing.T) {
+	var calls []*problem.Problem
+	gen := &problem.Generator{
+		Logger: func(_ context.Context, level problem.LogLevel, msg string, args ...any) {
+			assert.Equal(t, problem.LogLevelError, level)
+			assert.Equal(t, "bulk failed", msg)
+			calls = append(calls, args[len(args)-1].(*problem.Problem))
+		},
+		Negotiation: problem.NegotiationLenient,
+	}
+
+	var set problem.ProblemSet
+	set.SucceedAt(0, http.StatusCreated)
+	set.FailAt(1, gen.New(problem.WithStatus(http.StatusConflict), problem.WithLogLevel(problem.LogLevelWarn)))
+	set.FailAt(2, gen.New(problem.WithStatus(http.StatusInternalServerError)))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Accept", problem.ContentTypeXML)
+	require.NoError(t, gen.WriteProblemSet(&set, rec, req, problem.WriteOptions{LogMessage: "bulk failed"}))
+
+	assert.Equal(t, http.StatusMultiStatus, rec.Code)
+	assert.Equal(t, problem.ContentTypeGenericXMLUTF8, rec.Header().Get("Content-Type"))
+	var decoded problem.ProblemSet
+	require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &decoded))
+	assert.Equal(t, 3, decoded.Len())
+
+	require.Len(t, calls, 1)
+	assert.Equal(t, "2 of 3 items failed", calls[0].Detail)
+	assert.Equal(t, []string{"1", "2"}, calls[0].Extensions["failed"])
+	assert.Len(t, calls[0].Causes(), 2)
+	assert.Equal(t, http.StatusMultiStatus, calls[0].Status)
+	assert.Equal(t, "Multi-Status", calls[0].Title)
+	assert.Equal(t, problem.DefaultTypeURI, calls[0].Type)
+	assert.Equal(t, problem.LogLevelError, calls[0].LogInfo().Level)
+
+	rec = httptest.NewRecorder()
+	calls = nil
+	var succeeded problem.ProblemSet
+	succeeded.SucceedAt(0, http.StatusCreated)
+	require.NoError(t, gen.WriteProblemSetJSON(&succeeded, rec, req, problem.WriteOptions{ContentType: problem.ContentTypeJSON}))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, problem.ContentTypeGenericJSONUTF8, rec.Header().Get("Content-Type"))
+	assert.Empty(t, calls)
+}
+
+func TestWriteProblemSet_Typer(t *testing.T) {
+	var logged *problem.Problem
+	gen := &problem.Generator{
+		Logger: func(_ context.Context, _ problem.LogLevel, _ string, args ...any) {
+			logged = args[len(args)-1].(*problem.Problem)
+		},
+		Typer: func(defType problem.Type) string {
+			return fmt.Sprintf("https://example.com/problems/%d", defType.Status)
+		},
+	}
+
+	var set problem.ProblemSet
+	set.FailAt(0, gen.New(problem.WithStatus(http.StatusBadRequest), problem.WithLogLevel(problem.LogLevelInfo)))
+	set.FailAt(1, gen.New(problem.WithStatus(http.StatusBadRequest), problem.WithLogLevel(problem.LogLevelInfo)))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	require.NoError(t, gen.WriteProblemSet(&set, rec, req, problem.WriteOptions{LogMessage: "bulk failed"}))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	require.NotNil(t, logged)
+	assert.Equal(t, "https://example.com/problems/207", logged.Type)
+	assert.Equal(t, http.StatusBadRequest, logged.Status)
+	assert.Equal(t, "Bad Request", logged.Title)
+	assert.Equal(t, problem.LogLevelInfo, logged.LogInfo().Level)
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import "context"
+
+type (
+	// Redactor is a function used by a Generator to redact a Problem before it is written to an HTTP response (e.g. via
+	// Generator.WriteProblem) or otherwise serialized for a client using Generator.Redact, typically to avoid leaking
+	// information that is only intended for internal use (e.g. stack traces or the details of wrapped database errors).
+	//
+	// A Redactor is only ever passed a clone of a Problem, which it is free to modify, so that the original Problem,
+	// including when it is logged, always contains the full data. The context.Context passed contains the Generator
+	// performing the redaction, which can be retrieved using GetGenerator (e.g. to resolve translation keys), and, when
+	// the Problem is being written to an HTTP response, the status code written, which can be retrieved using
+	// GetResponseStatus.
+	Redactor func(ctx context.Context, prob *Problem)
+
+	// RedactionPolicy contains the rules used to redact a Problem, and can be used as a Redactor via
+	// RedactionPolicy.Redactor.
+	//
+	// For example;
+	//
+	//	policy := RedactionPolicy{Rules: []RedactionRule{
+	//		{RemoveStack: true},
+	//		{Matchers: []Matcher{HasStatusClass(5)}, ReplaceDetail: "An internal error has occurred"},
+	//		{RemoveExtensions: []string{"query", "sql"}},
+	//	}}
+	//	g := &Generator{Redactor: policy.Redactor()}
+	RedactionPolicy struct {
+		// Rules contains each RedactionRule to be applied, in order, to a Problem.
+		Rules []RedactionRule
+	}
+
+	// RedactionRule describes how a Problem is to be redacted, where it matches all the matchers provided.
+	//
+	// When the Problem is being written to an HTTP response, it is matched using the status code written (see
+	// GetResponseStatus) in place of Problem.Status, so that a status overridden using WriteOptions.Status is honored.
+	RedactionRule struct {
+		// Matchers contains each Matcher that a Problem must match for the rule to be applied.
+		//
+		// If empty, the rule is applied to all problems.
+		Matchers []Matcher
+		// RemoveExtensions contains the keys of any extensions to be removed (e.g. those known to contain sensitive
+		// information).
+		RemoveExtensions []string
+		// RemoveStack is whether any captured stack trace is to be removed from Problem.Stack.
+		RemoveStack bool
+		// ReplaceDetail is the generic detail used to replace Problem.Detail.
+		//
+		// If ReplaceDetailKey is not empty and can be resolved it will take precedence over ReplaceDetail. If both are
+		// empty, Problem.Detail is left unchanged.
+		ReplaceDetail string
+		// ReplaceDetailKey is the translation key of the generic detail used to replace Problem.Detail.
+		//
+		// The localized detail will be looked up using Generator.Translator, where possible. If resolved, it will take
+		// precedence over ReplaceDetail.
+		ReplaceDetailKey any
+	}
+)
+
+const (
+	// DefaultRedactedDetail is the generic detail used by ProductionRedactor to replace the detail of a Problem with a
+	// 5xx status.
+	DefaultRedactedDetail = "An internal error has occurred"
+	// DefaultRedactedDetailKey is the translation key of the generic detail used by ProductionRedactor to replace the
+	// detail of a Problem with a 5xx status.
+	DefaultRedactedDetailKey = "problem.redacted.detail"
+)
+
+// Redactor returns a Redactor that applies each RedactionRule within the RedactionPolicy, in order, to a Problem.
+func (rp RedactionPolicy) Redactor() Redactor {
+	return func(ctx context.Context, prob *Problem) {
+		for _, rule := range rp.Rules {
+			rule.apply(ctx, prob)
+		}
+	}
+}
+
+// apply applies the RedactionRule to the given Problem, if it matches all matchers.
+func (rr RedactionRule) apply(ctx context.Context, prob *Problem) {
+	if !Match(redactionTarget(ctx, prob), rr.Matchers...) {
+		return
+	}
+	if len(rr.RemoveExtensions) > 0 {
+		for _, key := range rr.RemoveExtensions {
+			delete(prob.Extensions, key)
+		}
+		if len(prob.Extensions) == 0 {
+			prob.Extensions = nil
+		}
+	}
+	if rr.RemoveStack {
+		prob.Stack = ""
+	}
+	if rr.ReplaceDetail != "" || rr.ReplaceDetailKey != nil {
+		prob.Detail = GetGenerator(ctx).translateOrElse(ctx, rr.ReplaceDetailKey, rr.ReplaceDetail)
+	}
+}
+
+// redactionTarget returns the Problem to be matched against the matchers of a RedactionRule, which is a shallow copy of
+// the given Problem with the status code of the HTTP response to which it is being written, if any and different.
+func redactionTarget(ctx context.Context, prob *Problem) *Problem {
+	status := GetResponseStatus(ctx)
+	if status == 0 || status == prob.Status {
+		return prob
+	}
+	target := *prob
+	target.Status = status
+	return &target
+}
+
+// Redact returns the given Problem redacted using Generator.Redactor, intended for use by any serializer that exposes a
+// Problem to a client. Problems written to HTTP responses by the Generator are redacted automatically.
+//
+// If Generator.Redactor is nil, prob is returned unchanged. Otherwise, a redacted clone of prob is returned and prob is
+// never modified.
+func (g *Generator) Redact(ctx context.Context, prob *Problem) *Problem {
+	r := g.Redactor
+	if r == nil || prob == nil {
+		return prob
+	}
+	c := prob.Clone()
+	r(UsingGenerator(ctx, g), c)
+	return c
+}
+
+// redactResponse returns the given Problem redacted using Generator.Redactor, if any, in the same way as
+// Generator.Redact, except that the status code of the HTTP response to which it is being written is passed to the
+// Redactor.
+func (g *Generator) redactResponse(ctx context.Context, prob *Problem, status int) *Problem {
+	return g.Redact(usingResponseStatus(ctx, status), prob)
+}
+
+// ProductionRedactor returns a Redactor suitable for production environments, which removes any captured stack trace
+// and the extensions with the given keys from all problems, while also replacing the detail of any Problem with a 5xx
+// status, or written to an HTTP response with a 5xx status, with a generic detail, localized using
+// DefaultRedactedDetailKey where possible, otherwise DefaultRedactedDetail.
+func ProductionRedactor(sensitiveExtensions ...string) Redactor {
+	return RedactionPolicy{Rules: []RedactionRule{
+		{RemoveExtensions: sensitiveExtensions, RemoveStack: true},
+		{
+			Matchers:         []Matcher{HasStatusClass(5)},
+			ReplaceDetail:    DefaultRedactedDetail,
+			ReplaceDetailKey: DefaultRedactedDetailKey,
+		},
+	}}.Redactor()
+}
+
+// Redact is a convenient shorthand for calling Generator.Redact on the Generator within the given context.Context, if
+// any, otherwise DefaultGenerator.
+func Redact(ctx context.Context, prob *Problem) *Problem {
+	return GetGenerator(ctx).Redact(ctx, prob)
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductionRedactor(t *testing.T) {
+	gen := &problem.Generator{
+		Redactor: problem.ProductionRedactor("query"),
+		Translator: func(_ context.Context, key any) string {
+			if key == problem.DefaultRedactedDetailKey {
+				return "Une erreur interne est survenue"
+			}
+			return ""
+		},
+	}
+	prob := gen.New(
+		problem.WithDetail("pq: relation \"users\" does not exist"),
+		problem.WithExtension("query", "SELECT * FROM users"),
+		problem.WithExtension("retryable", false),
+		problem.WithStack(),
+	)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, gen.WriteProblem(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]any{
+		"detail":    "Une erreur interne est survenue",
+		"retryable": false,
+		"status":    float64(http.StatusInternalServerError),
+		"title":     problem.DefaultTitle,
+		"type":      problem.DefaultTypeURI,
+	}, body)
+
+	// The original problem, as logged, is never modified
+	assert.Equal(t, "pq: relation \"users\" does not exist", prob.Detail)
+	assert.NotEmpty(t, prob.Stack)
+	assert.Contains(t, prob.Extensions, "query")
+
+	redacted := gen.Redact(context.Background(), gen.New(problem.WithStatus(http.StatusNotFound), problem.WithDetail("No user")))
+	assert.Equal(t, "No user", redacted.Detail)
+}
+
+func TestProductionRedactor_ResponseStatus(t *testing.T) {
+	var statuses []int
+	redactor := problem.ProductionRedactor()
+	gen := &problem.Generator{Redactor: func(ctx context.Context, prob *problem.Problem) {
+		statuses = append(statuses, problem.GetResponseStatus(ctx))
+		redactor(ctx, prob)
+	}}
+	prob := gen.New(problem.WithStatus(http.StatusOK), problem.WithDetail("pq: connection refused"))
+	opts := problem.WriteOptions{LogDisabled: true, Status: http.StatusInternalServerError}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, gen.WriteProblemJSON(prob, rec, req, opts))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), problem.DefaultRedactedDetail)
+	assert.NotContains(t, rec.Body.String(), "pq: connection refused")
+
+	rec = httptest.NewRecorder()
+	require.NoError(t, gen.WriteProblemHTML(prob, rec, req, opts))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), problem.DefaultRedactedDetail)
+	assert.NotContains(t, rec.Body.String(), "pq: connection refused")
+
+	var set problem.ProblemSet
+	set.FailAt(0, prob)
+	rec = httptest.NewRecorder()
+	require.NoError(t, gen.WriteProblemSetJSON(&set, rec, req, opts))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), problem.DefaultRedactedDetail)
+	assert.NotContains(t, rec.Body.String(), "pq: connection refused")
+
+	// Without an overridden status, the status of the problem is used
+	rec = httptest.NewRecorder()
+	require.NoError(t, gen.WriteProblemJSON(prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "pq: connection refused")
+
+	redacted := gen.Redact(context.Background(), prob)
+	assert.Equal(t, "pq: connection refused", redacted.Detail)
+
+	assert.Equal(t, []int{
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+		http.StatusOK,
+		0,
+	}, statuses)
+	assert.Equal(t, "pq: connection refused", prob.Detail)
+}
+
+func TestRedactionPolicy(t *testing.T) {
+	gen := &problem.Generator{Redactor: problem.RedactionPolicy{Rules: []problem.RedactionRule{
+		{Matchers: []problem.Matcher{problem.HasStatus(http.StatusConflict)}, RemoveExtensions: []string{"owner"}},
+		{Matchers: []problem.Matcher{problem.HasStatusClass(4)}, ReplaceDetail: "Invalid request"},
+	}}.Redactor()}
+
+	redacted := gen.Redact(context.Background(), gen.New(
+		problem.WithStatus(http.StatusConflict),
+		problem.WithDetail("Already owned by alice"),
+		problem.WithExtension("owner", "alice"),
+	))
+	assert.Equal(t, "Invalid request", redacted.Detail)
+	assert.Nil(t, redacted.Extensions)
+
+	assert.Nil(t, (&problem.Generator{}).Redact(context.Background(), nil))
+}
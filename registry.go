@@ -0,0 +1,278 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+type (
+	// Registry contains Definition and/or Go types registered against problem type URI references, allowing problems
+	// that are decoded (e.g. via Problem.UnmarshalJSON, Problem.UnmarshalXML, or ReadResponse) to be linked back to the
+	// Definition that describes them and/or be decoded into a concrete typed value.
+	//
+	// For example;
+	//
+	//	var OutOfCreditDefinition = problem.Definition{
+	//		Type: problem.Type{
+	//			Status: http.StatusForbidden,
+	//			Title:  "You do not have enough credit.",
+	//			URI:    "https://example.com/probs/out-of-credit",
+	//		},
+	//	}
+	//
+	//	type OutOfCredit struct {
+	//		Balance  int      `json:"balance"`
+	//		Accounts []string `json:"accounts"`
+	//	}
+	//
+	//	var registry problem.Registry
+	//	registry.MustRegister(OutOfCreditDefinition)
+	//	problem.MustRegisterValue[OutOfCredit](&registry, OutOfCreditDefinition.Type.URI)
+	//
+	//	gen := &problem.Generator{Registry: &registry}
+	//	prob, err := gen.ReadResponse(resp)
+	//	// ...
+	//	if problem.IsDefinition(prob, OutOfCreditDefinition) {
+	//		credit, _ := problem.ValueAs[OutOfCredit](prob)
+	//		// ...
+	//	}
+	//
+	// The zero value is ready to use and a Registry is safe for concurrent use. A Registry must not be copied after
+	// first use.
+	Registry struct {
+		// entries contains the registrations mapped to their type URI references.
+		entries map[string]registryEntry
+		// mu is used to guard entries.
+		mu sync.RWMutex
+	}
+
+	// registryEntry contains everything registered against a single type URI reference within a Registry.
+	registryEntry struct {
+		// def is the registered Definition, if any.
+		def *Definition
+		// decode is used to decode the JSON representation of a Problem into a registered Go type, if any.
+		decode func(data []byte) (any, error)
+	}
+)
+
+// ErrRegistry is returned when a Definition or Go type cannot be registered within a Registry.
+var ErrRegistry = errors.New("invalid problem registration")
+
+// Definitions returns a copy of all definitions within the Registry, sorted by their type URI references.
+func (r *Registry) Definitions() []Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	defs := make([]Definition, 0, len(r.entries))
+	for _, typeURI := range slices.Sorted(maps.Keys(r.entries)) {
+		if def := r.entries[typeURI].def; def != nil {
+			defs = append(defs, *def)
+		}
+	}
+	return defs
+}
+
+// Lookup returns the Definition registered within the Registry against the given type URI reference, if any.
+func (r *Registry) Lookup(typeURI string) (Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if def := r.entries[typeURI].def; def != nil {
+		return *def, true
+	}
+	return Definition{}, false
+}
+
+// MustRegister is a convenient shorthand for calling Registry.Register for each of the given definitions that panics if
+// it returns an error.
+func (r *Registry) MustRegister(defs ...Definition) {
+	for _, def := range defs {
+		if err := r.Register(def); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register registers the given Definition within the Registry against its Type.URI.
+//
+// An error is returned if Type.URI is empty or another Definition has already been registered against it.
+func (r *Registry) Register(def Definition) error {
+	typeURI := def.Type.URI
+	if typeURI == "" {
+		return fmt.Errorf("%w: definition type URI cannot be empty", ErrRegistry)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := r.entries[typeURI]
+	if entry.def != nil {
+		return fmt.Errorf("%w: definition already registered for type URI %q", ErrRegistry, typeURI)
+	}
+	entry.def = &def
+	r.put(typeURI, entry)
+	return nil
+}
+
+// RegisterType is a convenient shorthand for calling Registry.Register with a Definition containing only the given
+// Type.
+func (r *Registry) RegisterType(defType Type) error {
+	return r.Register(Definition{Type: defType})
+}
+
+// lookup returns a pointer to the Definition registered within the Registry against the given type URI reference, if
+// any. Unlike Registry.Lookup, lookup can be safely called on a nil Registry.
+func (r *Registry) lookup(typeURI string) (*Definition, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	def := r.entries[typeURI].def
+	return def, def != nil
+}
+
+// put stores the given registryEntry against the type URI reference provided, lazily initializing the entries.
+//
+// r.mu must be locked for writing by the caller.
+func (r *Registry) put(typeURI string, entry registryEntry) {
+	if r.entries == nil {
+		r.entries = make(map[string]registryEntry)
+	}
+	r.entries[typeURI] = entry
+}
+
+// resolve links the given Problem to the Definition registered against its type URI reference, if any, marking it as
+// retryable if the Type of the Definition is retryable, and decodes it into the Go type registered against it, if any.
+//
+// data is expected to be the JSON representation of the Problem, if available. Otherwise, if nil, the Problem is
+// marshaled into JSON when needed. If the Problem cannot be decoded into the registered Go type, it is not treated as
+// an error but Problem.Value will return nil.
+func (r *Registry) resolve(p *Problem, data []byte) {
+	if r == nil || p == nil || p.Type == "" {
+		return
+	}
+
+	r.mu.RLock()
+	entry, found := r.entries[p.Type]
+	r.mu.RUnlock()
+	if !found {
+		return
+	}
+
+	p.definition = entry.def
+	if entry.def != nil && entry.def.Type.Retryable {
+		p.retry.retryable = true
+	}
+	if entry.decode == nil {
+		return
+	}
+	if data == nil {
+		var err error
+		if data, err = json.Marshal(p); err != nil {
+			return
+		}
+	}
+	if v, err := entry.decode(data); err == nil {
+		p.value = v
+	}
+}
+
+// DecodeJSON decodes a Problem from the given JSON data in the same way as Problem.UnmarshalJSON, however, linking it
+// to any Definition and decoding it into any Go type registered within Generator.Registry, rather than the Registry of
+// DefaultGenerator.
+//
+// An error is returned if unable to decode data.
+func (g *Generator) DecodeJSON(data []byte) (*Problem, error) {
+	var prob Problem
+	if err := decodeJSONProblem(data, &prob); err != nil {
+		return nil, err
+	}
+	g.Registry.resolve(&prob, data)
+	return &prob, nil
+}
+
+// DecodeXML decodes a Problem from the given XML data in the same way as Problem.UnmarshalXML, however, linking it to
+// any Definition and decoding it into any Go type registered within Generator.Registry, rather than the Registry of
+// DefaultGenerator.
+//
+// An error is returned if unable to decode data.
+func (g *Generator) DecodeXML(data []byte) (*Problem, error) {
+	var prob Problem
+	if err := xml.Unmarshal(data, (*xmlProblem)(&prob)); err != nil {
+		return nil, err
+	}
+	g.Registry.resolve(&prob, nil)
+	return &prob, nil
+}
+
+// MustRegisterValue is a convenient shorthand for calling RegisterValue that panics if it returns an error.
+func MustRegisterValue[T any](r *Registry, typeURI string) {
+	if err := RegisterValue[T](r, typeURI); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterValue registers T within the given Registry against the type URI reference provided so that any problem of
+// that type that is decoded will also be decoded into a T, which can then be obtained via Problem.Value or ValueAs.
+//
+// Problems are decoded into a T from their JSON representation so T would typically be a struct whose fields are
+// tagged to reflect the members of the problem (e.g. extensions) that are of interest. T must not be, or embed, Problem
+// as this would result in infinite recursion. Since XML carries no type information, problems decoded from XML can only
+// be decoded into a T whose fields are compatible with strings, slices, and maps (see Extensions.UnmarshalXML for more
+// information). Problems that cannot be decoded into a T are otherwise unaffected but will have no value.
+//
+// An error is returned if typeURI is empty or another Go type has already been registered against it.
+func RegisterValue[T any](r *Registry, typeURI string) error {
+	if typeURI == "" {
+		return fmt.Errorf("%w: value type URI cannot be empty", ErrRegistry)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := r.entries[typeURI]
+	if entry.decode != nil {
+		return fmt.Errorf("%w: value already registered for type URI %q", ErrRegistry, typeURI)
+	}
+	entry.decode = func(data []byte) (any, error) {
+		var t T
+		err := json.Unmarshal(data, &t)
+		return t, err
+	}
+	r.put(typeURI, entry)
+	return nil
+}
+
+// ValueAs returns the value decoded from the given Problem into T, if any. See RegisterValue for more information.
+func ValueAs[T any](p *Problem) (T, bool) {
+	t, ok := p.Value().(T)
+	return t, ok
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOutOfCreditDefinition = problem.Definition{
+	Type: problem.Type{
+		Status: http.StatusForbidden,
+		Title:  "You do not have enough credit.",
+		URI:    "https://example.com/probs/out-of-credit",
+	},
+}
+
+type testOutOfCredit struct {
+	Accounts []string `json:"accounts"`
+	Balance  int      `json:"balance"`
+}
+
+func TestRegistry(t *testing.T) {
+	var registry problem.Registry
+	registry.MustRegister(testOutOfCreditDefinition)
+	problem.MustRegisterValue[testOutOfCredit](&registry, testOutOfCreditDefinition.Type.URI)
+
+	assert.ErrorIs(t, registry.Register(testOutOfCreditDefinition), problem.ErrRegistry)
+	assert.ErrorIs(t, problem.RegisterValue[testOutOfCredit](&registry, testOutOfCreditDefinition.Type.URI), problem.ErrRegistry)
+	assert.ErrorIs(t, registry.Register(problem.Definition{}), problem.ErrRegistry)
+	assert.Equal(t, []problem.Definition{testOutOfCreditDefinition}, registry.Definitions())
+
+	gen := &problem.Generator{Registry: &registry}
+	sent := gen.New(
+		problem.FromDefinition(testOutOfCreditDefinition),
+		problem.WithExtension("accounts", []string{"/account/12345"}),
+		problem.WithExtension("balance", 30),
+	)
+	assert.True(t, problem.IsDefinition(sent, testOutOfCreditDefinition))
+
+	for _, contentType := range []string{problem.ContentTypeJSONUTF8, problem.ContentTypeXMLUTF8} {
+		t.Run(contentType, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, gen.WriteProblem(sent, rec, req, problem.WriteOptions{ContentType: contentType, LogDisabled: true}))
+
+			received, err := gen.ReadResponse(rec.Result())
+			require.NoError(t, err)
+			assert.True(t, problem.IsDefinition(received, testOutOfCreditDefinition))
+			assert.False(t, problem.IsDefinition(received, problem.Definition{Type: problem.Type{URI: "other"}}))
+
+			if contentType == problem.ContentTypeJSONUTF8 {
+				value, ok := problem.ValueAs[testOutOfCredit](received)
+				require.True(t, ok)
+				assert.Equal(t, testOutOfCredit{Accounts: []string{"/account/12345"}, Balance: 30}, value)
+			} else {
+				// XML carries no type information so numeric extensions cannot be decoded into an int
+				assert.Nil(t, received.Value())
+			}
+		})
+	}
+}
+
+func TestRegistry_DefinitionType(t *testing.T) {
+	var registry problem.Registry
+	registry.MustRegister(testOutOfCreditDefinition)
+	gen := &problem.Generator{Registry: &registry}
+
+	prob := gen.New(problem.FromType(testOutOfCreditDefinition.Type))
+	assert.True(t, problem.IsDefinition(prob, testOutOfCreditDefinition), "registered definition should be linked")
+
+	prob = gen.New(problem.FromType(problem.Type{Status: http.StatusTeapot}))
+	_, found := prob.Definition()
+	assert.False(t, found, "type alone should not be linked to a definition")
+
+	prob = gen.New(problem.FromDefinition(testOutOfCreditDefinition), problem.FromType(problem.Type{URI: "other"}))
+	_, found = prob.Definition()
+	assert.False(t, found, "overridden definition should not be linked")
+}
+
+func TestGenerator_Decode(t *testing.T) {
+	var registry problem.Registry
+	registry.MustRegister(testOutOfCreditDefinition)
+	problem.MustRegisterValue[testOutOfCredit](&registry, testOutOfCreditDefinition.Type.URI)
+	gen := &problem.Generator{Registry: &registry}
+	sent := gen.New(problem.FromDefinition(testOutOfCreditDefinition), problem.WithExtension("balance", 30))
+
+	data, err := json.Marshal(sent)
+	require.NoError(t, err)
+	received, err := gen.DecodeJSON(data)
+	require.NoError(t, err)
+	assert.True(t, problem.IsDefinition(received, testOutOfCreditDefinition))
+	value, ok := problem.ValueAs[testOutOfCredit](received)
+	require.True(t, ok)
+	assert.Equal(t, 30, value.Balance)
+
+	var unmarshaled problem.Problem
+	require.NoError(t, json.Unmarshal(data, &unmarshaled))
+	assert.False(t, problem.IsDefinition(&unmarshaled, testOutOfCreditDefinition), "DefaultGenerator has no registry")
+
+	data, err = xml.Marshal(sent)
+	require.NoError(t, err)
+	received, err = gen.DecodeXML(data)
+	require.NoError(t, err)
+	assert.True(t, problem.IsDefinition(received, testOutOfCreditDefinition))
+
+	_, err = gen.DecodeJSON([]byte(`[]`))
+	assert.Error(t, err)
+	_, err = gen.DecodeXML([]byte(`<problem>`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// retryInfo contains the information describing whether, and when, the request that resulted in a Problem may be
+// retried.
+type retryInfo struct {
+	// after is the delay after which the request may be retried, if any.
+	after time.Duration
+	// at is the time at which the request may be retried, if any, which takes precedence over after.
+	at time.Time
+	// retryable is whether the request may be retried.
+	retryable bool
+}
+
+// retryAfterHeader is the header representing how long a client ought to wait before making a follow-up request.
+const retryAfterHeader = "Retry-After"
+
+// RetryAfter returns the delay after which the request that resulted in the Problem may be retried, if any.
+//
+// If the delay was provided as a point in time (e.g. using Builder.RetryAt or read from an HTTP-date within the
+// Retry-After header of an HTTP response), the time remaining until that point is returned, which may be zero if it
+// has already passed.
+func (p *Problem) RetryAfter() (time.Duration, bool) {
+	if p == nil {
+		return 0, false
+	}
+	if at := p.retry.at; !at.IsZero() {
+		return max(time.Until(at), 0), true
+	}
+	return p.retry.after, p.retry.after > 0
+}
+
+// Retryable returns whether the request that resulted in the Problem is considered transient and may be retried.
+//
+// A Problem is retryable if explicitly stated (e.g. using Builder.Retryable), if its Type is retryable (see
+// Type.Retryable), or if it has a retry delay (see Problem.RetryAfter). A Problem read from an HTTP response (e.g. using
+// ReadResponse) is retryable if the HTTP response has a Retry-After header or the Type of any Definition registered
+// against its type URI reference is retryable.
+func (p *Problem) Retryable() bool {
+	return p != nil && p.retry.retryable
+}
+
+// header returns the value of the Retry-After header for the retryInfo, or an empty string if it has no delay.
+//
+// A point in time is formatted as an HTTP-date, while a delay is formatted as a number of seconds, rounded up.
+func (ri retryInfo) header() string {
+	if !ri.at.IsZero() {
+		return ri.at.UTC().Format(http.TimeFormat)
+	}
+	if ri.after > 0 {
+		return strconv.FormatInt(int64(math.Ceil(ri.after.Seconds())), 10)
+	}
+	return ""
+}
+
+// parseRetryAfter returns the retryInfo for the given value of a Retry-After header, which may contain either a number
+// of seconds or an HTTP-date, and whether it could be parsed.
+func parseRetryAfter(value string) (retryInfo, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return retryInfo{}, false
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if secs < 0 || secs > int64(math.MaxInt64/time.Second) {
+			return retryInfo{}, false
+		}
+		return retryInfo{after: time.Duration(secs) * time.Second, retryable: true}, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return retryInfo{at: at, retryable: true}, true
+	}
+	return retryInfo{}, false
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jay-babu/go-problem"
+	problemhttp "github.com/jay-babu/go-problem/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_RetryAfter(t *testing.T) {
+	prob := problemhttp.TooManyRequestsDefinition.New()
+	assert.True(t, problem.Match(prob, problem.IsRetryable()))
+	assert.False(t, problem.Match(prob, problem.HasRetryAfter()))
+
+	prob = problem.New(problem.WithRetryAfter(1500 * time.Millisecond))
+	assert.True(t, prob.Retryable())
+	delay, found := prob.RetryAfter()
+	assert.True(t, found)
+	assert.Equal(t, 1500*time.Millisecond, delay)
+
+	prob = problem.New(
+		problem.FromDefinition(problem.Definition{RetryAfter: time.Minute, Type: problemhttp.ServiceUnavailable}),
+		problem.WithRetryable(false),
+	)
+	assert.False(t, prob.Retryable())
+	delay, _ = prob.RetryAfter()
+	assert.Equal(t, time.Minute, delay)
+
+	wrapped := problem.New(problem.Wrap(prob, problem.FullUnwrapper()))
+	delay, _ = wrapped.RetryAfter()
+	assert.Equal(t, time.Minute, delay)
+	assert.False(t, problem.New().Retryable())
+}
+
+func TestWriteProblem_RetryAfter(t *testing.T) {
+	at := time.Date(2030, time.January, 2, 3, 4, 5, 0, time.UTC)
+	testCases := map[string]struct {
+		prob     *problem.Problem
+		expected string
+	}{
+		"seconds": {
+			prob:     problem.New(problem.WithRetryAfter(1500 * time.Millisecond)),
+			expected: "2",
+		},
+		"date": {
+			prob:     problem.New(problem.WithRetryAt(at)),
+			expected: "Wed, 02 Jan 2030 03:04:05 GMT",
+		},
+		"none": {
+			prob: problem.New(problem.WithRetryable(true)),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, problem.WriteProblem(tc.prob, rec, req, problem.WriteOptions{LogDisabled: true}))
+			assert.Equal(t, tc.expected, rec.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestReadResponse_RetryAfter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	sent := problemhttp.ServiceUnavailableDefinition.New(problem.WithRetryAfter(30 * time.Second))
+	require.NoError(t, problem.WriteProblem(sent, rec, req, problem.WriteOptions{LogDisabled: true}))
+
+	received, err := problem.ReadResponse(rec.Result())
+	require.NoError(t, err)
+	assert.True(t, received.Retryable())
+	delay, found := received.RetryAfter()
+	assert.True(t, found)
+	assert.Equal(t, 30*time.Second, delay)
+
+	resp := &http.Response{
+		Header:     http.Header{"Retry-After": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}},
+		StatusCode: http.StatusServiceUnavailable,
+	}
+	received, err = problem.ReadResponse(resp)
+	require.NoError(t, err)
+	assert.True(t, problem.Match(received, problem.IsRetryable(), problem.HasRetryAfter()))
+	delay, _ = received.RetryAfter()
+	assert.InDelta(t, time.Hour, delay, float64(5*time.Second))
+
+	resp.Header.Set("Retry-After", "soon")
+	received, err = problem.ReadResponse(resp)
+	require.NoError(t, err)
+	assert.False(t, received.Retryable())
+}
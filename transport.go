@@ -0,0 +1,146 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import "net/http"
+
+type (
+	// Transport is an http.RoundTripper that converts HTTP responses containing a problem document into errors, allowing
+	// problems received from other services to be handled in the same way as those generated locally (e.g. using As,
+	// AsMatch, and any Matcher).
+	//
+	// When an HTTP response has a status that is to be converted (see Transport.Statuses) and its Content-Type header
+	// indicates that its body contains a problem document, the problem document is read and a ResponseError is returned
+	// instead of the HTTP response. All other HTTP responses are returned unchanged, including those whose body exceeds
+	// Transport.MaxBodySize or cannot be decoded.
+	//
+	// In doing so, Transport deliberately deviates from the http.RoundTripper contract, which expects an error to be
+	// returned only when no HTTP response could be obtained. http.Client wraps the ResponseError in a *url.Error and any
+	// http.RoundTripper wrapping Transport (e.g. one that retries failed requests) will treat converted HTTP responses as
+	// failures to obtain a response. Where this is undesirable, use CheckResponse on the HTTP response instead.
+	//
+	// For example;
+	//
+	//	client := &http.Client{Transport: &Transport{}}
+	//	_, err := client.Get("https://api.example.void/users/123")
+	//	if IsMatch(err, HasStatus(http.StatusNotFound)) {
+	//		// Handle missing user
+	//	}
+	Transport struct {
+		// Base is the http.RoundTripper used to make HTTP requests.
+		//
+		// If nil, http.DefaultTransport will be used.
+		Base http.RoundTripper
+		// Generator is the Generator used to read problems from HTTP responses.
+		//
+		// If nil, the Generator within the HTTP request's context.Context, if any, otherwise DefaultGenerator will be
+		// used.
+		Generator *Generator
+		// KeepBody is whether the body of a converted HTTP response is retained so that it can be read by the caller via
+		// ResponseError.Response.
+		//
+		// By default, the body is closed and replaced with http.NoBody.
+		KeepBody bool
+		// MaxBodySize is the maximum number of bytes that will be read from the body of an HTTP response containing a
+		// problem document.
+		//
+		// If less than or equal to zero, DefaultMaxBodySize will be used.
+		MaxBodySize int64
+		// Statuses is used to decide whether an HTTP response with the given status is to be converted into an error.
+		//
+		// If nil, HTTP responses with a client or server error status (i.e. 4xx or 5xx) are converted.
+		//
+		// For example;
+		//
+		//	t := &Transport{Statuses: func(status int) bool {
+		//		return status >= http.StatusInternalServerError
+		//	}}
+		Statuses func(status int) bool
+	}
+
+	// ResponseError is returned by CheckResponse and Transport when an HTTP response containing a problem document has
+	// been converted into an error.
+	//
+	// As it wraps the Problem read from the HTTP response, As, AsMatch, and similar functions can be used to access it.
+	ResponseError struct {
+		// Problem is the Problem read from the HTTP response.
+		Problem *Problem
+		// Response is the HTTP response from which Problem was read.
+		//
+		// When returned by Transport, its body can only be read if Transport.KeepBody is true, otherwise it is
+		// http.NoBody.
+		Response *http.Response
+	}
+)
+
+var (
+	_ error             = (*ResponseError)(nil)
+	_ http.RoundTripper = (*Transport)(nil)
+)
+
+// RoundTrip executes a single HTTP transaction using Transport.Base, returning a nil HTTP response and a ResponseError
+// if the HTTP response contains a problem document and has a status that is to be converted. See Transport for more
+// information.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil || !t.converts(resp.StatusCode) || !IsProblemResponse(resp) {
+		return resp, err
+	}
+
+	gen := t.Generator
+	if gen == nil {
+		gen = GetGenerator(req.Context())
+	}
+	maxSize := t.MaxBodySize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxBodySize
+	}
+	prob, ok := gen.convertResponse(resp, maxSize)
+	if !ok {
+		return resp, nil
+	}
+	if !t.KeepBody {
+		resp.Body = http.NoBody
+	}
+	return nil, &ResponseError{Problem: prob, Response: resp}
+}
+
+// converts returns whether an HTTP response with the given status is to be converted into an error.
+func (t *Transport) converts(status int) bool {
+	if fn := t.Statuses; fn != nil {
+		return fn(status)
+	}
+	return isErrorStatus(status)
+}
+
+// Error returns the error message of the wrapped Problem.
+func (e *ResponseError) Error() string {
+	return e.Problem.Error()
+}
+
+// Unwrap returns the wrapped Problem.
+func (e *ResponseError) Unwrap() error {
+	return e.Problem
+}
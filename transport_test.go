@@ -0,0 +1,165 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc is a function that implements http.RoundTripper.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// newTestBase returns a roundTripFunc that returns an HTTP response with the given status code, Content-Type header,
+// and body.
+func newTestBase(status int, contentType, body string) roundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		resp := newTestResponse(status, contentType, body)
+		resp.Request = req
+		return resp, nil
+	}
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		prob := problem.New(problem.WithStatus(http.StatusNotFound), problem.WithTitle("Not Found"))
+		_ = problem.WriteProblem(prob, w, req, problem.WriteOptions{LogDisabled: true})
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &problem.Transport{}}
+	resp, err := client.Get(srv.URL)
+	assert.Nil(t, resp)
+	var urlErr *url.Error
+	require.ErrorAs(t, err, &urlErr)
+	var respErr *problem.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, http.StatusNotFound, respErr.Problem.Status)
+	assert.Equal(t, http.NoBody, respErr.Response.Body)
+	assert.True(t, problem.IsMatch(err, problem.HasStatus(http.StatusNotFound)))
+	prob, ok := problem.As(err)
+	require.True(t, ok)
+	assert.Equal(t, "Not Found", prob.Title)
+}
+
+func TestTransport_Statuses(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	body := `{"title": "Moved"}`
+
+	for _, status := range []int{http.StatusOK, http.StatusMultipleChoices, http.StatusNotModified} {
+		resp, err := (&problem.Transport{Base: newTestBase(status, problem.ContentTypeJSON, body)}).RoundTrip(req)
+		require.NoError(t, err, status)
+		assert.Equal(t, status, resp.StatusCode)
+	}
+	for _, status := range []int{http.StatusBadRequest, http.StatusServiceUnavailable} {
+		_, err := (&problem.Transport{Base: newTestBase(status, problem.ContentTypeJSON, body)}).RoundTrip(req)
+		assert.True(t, problem.IsMatch(err, problem.HasStatus(status)), status)
+	}
+
+	transport := &problem.Transport{
+		Base:     newTestBase(http.StatusBadRequest, problem.ContentTypeJSON, body),
+		Statuses: func(status int) bool { return status >= http.StatusInternalServerError },
+	}
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestTransport_KeepBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	body := `{"title": "Conflict"}`
+	transport := &problem.Transport{Base: newTestBase(http.StatusConflict, problem.ContentTypeJSON, body), KeepBody: true}
+
+	_, err := transport.RoundTrip(req)
+	var respErr *problem.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	b, err := io.ReadAll(respErr.Response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(b))
+}
+
+func TestTransport_Unconverted(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	tests := map[string]*problem.Transport{
+		"not problem": {Base: newTestBase(http.StatusBadGateway, "text/plain", "upstream connect error")},
+		"too large":   {Base: newTestBase(http.StatusBadGateway, problem.ContentTypeJSON, `{"title": "Bad Gateway"}`), MaxBodySize: 8},
+		"invalid":     {Base: newTestBase(http.StatusBadGateway, problem.ContentTypeJSON, `{"title": 1}`)},
+	}
+	for name, transport := range tests {
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err, name)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode, name)
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err, name)
+		assert.NotEmpty(t, b, name)
+		assert.NoError(t, resp.Body.Close(), name)
+	}
+
+	baseErr := errors.New("connection refused")
+	_, err := (&problem.Transport{Base: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, baseErr
+	})}).RoundTrip(req)
+	assert.Same(t, baseErr, err)
+}
+
+func TestCheckResponse(t *testing.T) {
+	body := `{"title": "Conflict"}`
+
+	resp := newTestResponse(http.StatusConflict, problem.ContentTypeJSON, body)
+	err := problem.CheckResponse(resp)
+	var respErr *problem.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Same(t, resp, respErr.Response)
+	assert.Equal(t, "Conflict", respErr.Problem.Title)
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(b))
+
+	for _, resp = range []*http.Response{
+		nil,
+		newTestResponse(http.StatusOK, problem.ContentTypeJSON, body),
+		newTestResponse(http.StatusFound, problem.ContentTypeJSON, body),
+		newTestResponse(http.StatusConflict, "text/plain", body),
+	} {
+		assert.NoError(t, problem.CheckResponse(resp))
+	}
+
+	resp = newTestResponse(http.StatusConflict, problem.ContentTypeJSON, body)
+	assert.NoError(t, problem.CheckResponse(resp, problem.ReadOptions{MaxBodySize: 8}))
+	b, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(b))
+
+	resp = &http.Response{Header: http.Header{"Content-Type": {problem.ContentTypeJSON}}, StatusCode: http.StatusConflict}
+	assert.NoError(t, problem.CheckResponse(resp))
+}
@@ -22,6 +22,7 @@ package problem
 
 import (
 	"context"
+	"time"
 
 	"github.com/neocotic/go-optional"
 )
@@ -34,6 +35,9 @@ type (
 	// where they can be used to dictate all information populated within a Problem and/or combined with options to
 	// provide more granular control and overrides.
 	Type struct {
+		// Docs contains optional human-readable documentation for the Type, which is never included when a Problem
+		// generated from the Type is serialized.
+		Docs Documentation `json:"docs,omitempty" xml:"docs,omitempty" yaml:"docs,omitempty"`
 		// LogLevel is the default LogLevel to be assigned to a Problem generated from the Type. See Problem.LogLevel for
 		// more information.
 		//
@@ -41,6 +45,16 @@ type (
 		//
 		// If LogLevel is zero, the default used is DefaultLogLevel.
 		LogLevel LogLevel `json:"logLevel" xml:"logLevel" yaml:"logLevel"`
+		// RetryAfter is the default delay after which the request that resulted in a Problem generated from the Type
+		// may be retried. See Problem.RetryAfter for more information.
+		//
+		// When a Problem with a delay is written to an HTTP response, the delay is included in the Retry-After header.
+		//
+		// If RetryAfter is less than or equal to zero, no default is used.
+		RetryAfter time.Duration `json:"retryAfter,omitempty" xml:"retryAfter,omitempty" yaml:"retryAfter,omitempty"`
+		// Retryable is whether a Problem generated from the Type is considered transient by default, indicating that the
+		// request that resulted in it may be retried. See Problem.Retryable for more information.
+		Retryable bool `json:"retryable,omitempty" xml:"retryable,omitempty" yaml:"retryable,omitempty"`
 		// Status is the default status to be assigned to a Problem generated from the Type. See Problem.Status for more
 		// information.
 		//
@@ -92,6 +106,25 @@ const (
 	// encoding.
 	ContentTypeXMLUTF8 = ContentTypeXML + "; charset=utf-8"
 
+	// ContentTypeGenericJSON is the generic content/media type that may be used to represent a problem in JSON format
+	// for clients that do not accept ContentTypeJSON.
+	ContentTypeGenericJSON = "application/json"
+	// ContentTypeGenericJSONUTF8 is the generic content/media type that may be used to represent a problem in JSON
+	// format with UTF-8 encoding for clients that do not accept ContentTypeJSON.
+	ContentTypeGenericJSONUTF8 = ContentTypeGenericJSON + "; charset=utf-8"
+	// ContentTypeGenericXML is the generic content/media type that may be used to represent a problem in XML format for
+	// clients that do not accept ContentTypeXML.
+	ContentTypeGenericXML = "application/xml"
+	// ContentTypeGenericXMLUTF8 is the generic content/media type that may be used to represent a problem in XML format
+	// with UTF-8 encoding for clients that do not accept ContentTypeXML.
+	ContentTypeGenericXMLUTF8 = ContentTypeGenericXML + "; charset=utf-8"
+	// ContentTypeHTML is the content/media type used to represent a problem as a human-readable HTML document (e.g. for
+	// browser clients).
+	ContentTypeHTML = "text/html"
+	// ContentTypeHTMLUTF8 is the content/media type used to represent a problem as a human-readable HTML document with
+	// UTF-8 encoding.
+	ContentTypeHTMLUTF8 = ContentTypeHTML + "; charset=utf-8"
+
 	// DefaultTypeURI is the default problem type URI, indicating that a problem has no additional semantics beyond that
 	// its status.
 	//
@@ -188,8 +221,14 @@ func (g *Generator) typeURI(defType Type) string {
 
 // isValidContentType returns whether the given content-type is valid when representing a Problem in any supported form.
 func isValidContentType(ct string) bool {
+	return isValidContentTypeForJSON(ct) || isValidContentTypeForXML(ct) || isValidContentTypeForHTML(ct)
+}
+
+// isValidContentTypeForHTML returns whether the given content-type is valid when representing a Problem in its HTML
+// form.
+func isValidContentTypeForHTML(ct string) bool {
 	switch ct {
-	case ContentTypeJSON, ContentTypeJSONUTF8, ContentTypeXML, ContentTypeXMLUTF8:
+	case ContentTypeHTML, ContentTypeHTMLUTF8:
 		return true
 	default:
 		return false
@@ -200,7 +239,7 @@ func isValidContentType(ct string) bool {
 // form.
 func isValidContentTypeForJSON(ct string) bool {
 	switch ct {
-	case ContentTypeJSON, ContentTypeJSONUTF8:
+	case ContentTypeJSON, ContentTypeJSONUTF8, ContentTypeGenericJSON, ContentTypeGenericJSONUTF8:
 		return true
 	default:
 		return false
@@ -210,7 +249,7 @@ func isValidContentTypeForJSON(ct string) bool {
 // isValidContentTypeForXML returns whether the given content-type is valid when representing a Problem in its XML form.
 func isValidContentTypeForXML(ct string) bool {
 	switch ct {
-	case ContentTypeXML, ContentTypeXMLUTF8:
+	case ContentTypeXML, ContentTypeXMLUTF8, ContentTypeGenericXML, ContentTypeGenericXMLUTF8:
 		return true
 	default:
 		return false
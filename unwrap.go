@@ -20,12 +20,32 @@
 
 package problem
 
-// Unwrapper is a function used by Builder.Wrap and Wrap to handle an already wrapped Problem in err's tree.
-//
-// An Unwrapper is effectively responsible for deciding what, if any, information from a wrapped Problem is to be used
-// to construct the new Problem. Any such information will not take precedence over any explicitly defined Problem
-// fields, however, it will take precedence over any information derived from a Definition or its Type.
-type Unwrapper func(err error) Problem
+type (
+	// CauseSelector is a function used by Builder.Wrap, Builder.WrapAll, and their corresponding options to select
+	// which of multiple causes is passed to an Unwrapper (i.e. from which cause information like a stack trace or
+	// "UUID" is inherited). The causes are those of the Problem being built (see Problem.Causes) and always contain at
+	// least two errors.
+	//
+	// If the function returns nil, no information is inherited from any cause.
+	CauseSelector func(causes []error) error
+
+	// Unwrapper is a function used by Builder.Wrap and Wrap to handle an already wrapped Problem in err's tree.
+	//
+	// An Unwrapper is effectively responsible for deciding what, if any, information from a wrapped Problem is to be
+	// used to construct the new Problem. Any such information will not take precedence over any explicitly defined
+	// Problem fields, however, it will take precedence over any information derived from a Definition or its Type.
+	//
+	// When multiple errors are wrapped, the Unwrapper is only passed the cause selected by Generator.CauseSelector.
+	Unwrapper func(err error) Problem
+)
+
+// FirstProblemCauseSelector returns a CauseSelector that selects the first cause that is a Describer or whose tree
+// contains a Problem (see As), otherwise the first cause.
+func FirstProblemCauseSelector() CauseSelector {
+	return func(causes []error) error {
+		return selectFirstProblemCause(nil, causes)
+	}
+}
 
 // FullUnwrapper returns an Unwrapper that extracts all fields from a wrapped Problem in err's tree, if present. These
 // fields will not take precedence over any explicitly defined Problem fields, however, it will take precedence over any
@@ -49,6 +69,57 @@ func PropagatedFieldUnwrapper() Unwrapper {
 	return unwrapPropagatedFields
 }
 
+// selectCause returns the cause of the given errors to be passed to an Unwrapper using Generator.CauseSelector, if not
+// nil, otherwise FirstProblemCauseSelector. If there is only one cause, it is always returned, and nil is returned if
+// there are none.
+func (g *Generator) selectCause(errs []error) error {
+	causes := flattenCauses(errs)
+	switch len(causes) {
+	case 0:
+		return nil
+	case 1:
+		return causes[0]
+	}
+	if cs := g.CauseSelector; cs != nil {
+		return cs(causes)
+	}
+	return selectFirstProblemCause(g, causes)
+}
+
+// flattenCauses returns the given errors, where any error that wraps multiple errors itself and is not a Problem (e.g.
+// the result of errors.Join) is replaced by those errors, recursively. nil is returned if errs is empty.
+func flattenCauses(errs []error) []error {
+	if len(errs) == 0 {
+		return nil
+	}
+	causes := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if _, isProblem := err.(*Problem); !isProblem {
+			if multi, ok := err.(interface{ Unwrap() []error }); ok {
+				causes = append(causes, flattenCauses(multi.Unwrap())...)
+				continue
+			}
+		}
+		if err != nil {
+			causes = append(causes, err)
+		}
+	}
+	return causes
+}
+
+// selectFirstProblemCause returns the first of the given causes whose tree contains a Problem (see As), otherwise the
+// first cause.
+//
+// Any Problem described by a cause is constructed using the given Generator, if not nil, otherwise DefaultGenerator.
+func selectFirstProblemCause(gen *Generator, causes []error) error {
+	for _, cause := range causes {
+		if _, isProblem := findProblem(gen, cause, nil); isProblem {
+			return cause
+		}
+	}
+	return causes[0]
+}
+
 // unwrapAllFields extracts all fields from a wrapped Problem in err's tree, if present. These fields will not take
 // precedence over any explicitly defined Problem fields, however, it will take precedence over any fields derived from
 // a Definition or its Type.
@@ -68,9 +139,13 @@ func unwrapAllFields(err error) Problem {
 func unwrapPropagatedFields(err error) Problem {
 	if p, isProblem := As(err); isProblem && p != nil {
 		return Problem{
-			Stack:   p.Stack,
-			UUID:    p.UUID,
-			logInfo: p.logInfo,
+			Stack: p.Stack,
+			UUID:  p.UUID,
+			logInfo: LogInfo{
+				Level: p.logInfo.Level,
+				Stack: p.logInfo.Stack,
+				UUID:  p.logInfo.UUID,
+			},
 		}
 	}
 	return Problem{}
@@ -0,0 +1,96 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapAll(t *testing.T) {
+	err1 := errors.New("item 1 failed")
+	err2 := errors.New("item 2 failed")
+	prob := problem.New(problem.WithStatus(http.StatusBadRequest), problem.WrapAll(err1, nil, err2))
+
+	assert.Equal(t, []error{err1, err2}, prob.Unwrap())
+	assert.Equal(t, []error{err1, err2}, prob.Causes())
+	assert.ErrorIs(t, prob, err1)
+	assert.ErrorIs(t, prob, err2)
+	assert.Equal(t, "400 Unknown Error: item 1 failed; item 2 failed", prob.Error())
+}
+
+func TestWrap_Join(t *testing.T) {
+	err1 := errors.New("item 1 failed")
+	err2 := errors.New("item 2 failed")
+	err3 := errors.New("item 3 failed")
+	joined := errors.Join(err1, errors.Join(err2, err3))
+	prob := problem.New(problem.Wrap(joined))
+
+	assert.Equal(t, []error{joined}, prob.Unwrap())
+	assert.Equal(t, []error{err1, err2, err3}, prob.Causes())
+	assert.ErrorIs(t, prob, err3)
+}
+
+func TestWrap_CauseSelector(t *testing.T) {
+	gen := &problem.Generator{UUIDFlag: problem.FlagField}
+	first := gen.New(problem.WithStatus(http.StatusConflict))
+	second := gen.New(problem.WithStatus(http.StatusGone))
+	plain := errors.New("plain")
+
+	prob := gen.New(problem.WrapAll(plain, first, second))
+	assert.Equal(t, first.UUID, prob.UUID)
+
+	gen.CauseSelector = func(causes []error) error {
+		return causes[len(causes)-1]
+	}
+	prob = gen.New(problem.Wrap(errors.Join(plain, first, second)))
+	assert.Equal(t, second.UUID, prob.UUID)
+
+	gen.Unwrapper = problem.FullUnwrapper()
+	prob = gen.New(problem.WrapAll(first, second))
+	assert.Equal(t, http.StatusGone, prob.Status)
+}
+
+func TestLogValue_Causes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	logger.Info("failed", "problem", problem.New(problem.WrapAll(errors.New("a"), errors.New("b"))))
+	var entry struct {
+		Problem map[string]any `json:"problem"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, map[string]any{"0": "a", "1": "b"}, entry.Problem["errors"])
+	assert.NotContains(t, entry.Problem, "error")
+
+	buf.Reset()
+	logger.Info("failed", "problem", problem.New(problem.Wrap(errors.New("a"))))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "a", entry.Problem["error"])
+}
@@ -25,7 +25,6 @@ package uri
 
 import (
 	"fmt"
-	"github.com/neocotic/go-optional"
 	"maps"
 	"net/url"
 	"slices"
@@ -0,0 +1,199 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type (
+	// FieldError describes a single validation error for a specific part of an HTTP request, identified by either a
+	// JSON Pointer into the request body, a query parameter name, or a header name.
+	//
+	// When a Problem is built with ValidationErrors, each FieldError is included within the extension with the
+	// ValidationErrorsExtensionKey, in accordance with the example in RFC 9457 Section 3. For example;
+	//
+	//	{
+	//		"type": "about:blank",
+	//		"status": 422,
+	//		"title": "Unprocessable Entity",
+	//		"errors": [
+	//			{"detail": "must be a positive integer", "pointer": "/age"},
+	//			{"detail": "must be 'green', 'red' or 'blue'", "pointer": "/profile/color"}
+	//		]
+	//	}
+	FieldError struct {
+		// Code is an optional Code used to uniquely identify the validation error. See Problem.Code for more information.
+		Code Code `json:"code,omitempty" xml:"code,omitempty"`
+		// Detail is a human-readable explanation specific to the validation error.
+		//
+		// If DetailKey is not empty and can be resolved it will take precedence over Detail.
+		Detail string `json:"detail" xml:"detail"`
+		// DetailKey is the translation key of the detail. The localized detail will be looked up using
+		// Generator.Translator, where possible, when a Problem is built. If resolved, it will take precedence over
+		// Detail.
+		//
+		// DetailKey is retained within a Problem built with ValidationErrors so that Generator.Localize can localize the
+		// detail again, however, DetailKey is never serialized.
+		DetailKey any `json:"-" xml:"-"`
+		// Header is the name of the HTTP request header that is invalid, if any.
+		Header string `json:"header,omitempty" xml:"header,omitempty"`
+		// Parameter is the name of the query parameter that is invalid, if any.
+		Parameter string `json:"parameter,omitempty" xml:"parameter,omitempty"`
+		// Pointer is the JSON Pointer (RFC 6901) into the request body identifying the value that is invalid, if any.
+		//
+		// JSONPointer can be used to aid building the JSON Pointer.
+		Pointer string `json:"pointer,omitempty" xml:"pointer,omitempty"`
+	}
+
+	// ValidationErrors is used to collect FieldError so that they can all be reported within a single Problem, which
+	// would typically be generated from either a 400 Bad Request or 422 Unprocessable Entity Definition (e.g.
+	// http.BadRequestDefinition or http.UnprocessableEntityDefinition).
+	//
+	// For example;
+	//
+	//	var errs problem.ValidationErrors
+	//	if req.Age <= 0 {
+	//		errs.Pointer("/age", "must be a positive integer")
+	//	}
+	//	if req.Profile.Color == "" {
+	//		errs.Add(problem.FieldError{
+	//			DetailKey: "validation.color.required",
+	//			Pointer:   problem.JSONPointer("profile", "color"),
+	//		})
+	//	}
+	//	if errs.Len() > 0 {
+	//		return http.UnprocessableEntityDefinition.New(problem.WithValidationErrors(&errs))
+	//	}
+	//
+	// The zero value is ready to use. A ValidationErrors is not safe for concurrent use.
+	ValidationErrors struct {
+		// errs contains the collected FieldError, in the order they were added.
+		errs []FieldError
+	}
+)
+
+// ValidationErrorsExtensionKey is the key of the extension containing the FieldError of a Problem built with
+// ValidationErrors.
+const ValidationErrorsExtensionKey = "errors"
+
+// Add appends the given FieldError to the ValidationErrors.
+func (ve *ValidationErrors) Add(fieldErrs ...FieldError) *ValidationErrors {
+	ve.errs = append(ve.errs, fieldErrs...)
+	return ve
+}
+
+// Errors returns a copy of the FieldError within the ValidationErrors, in the order they were added.
+func (ve *ValidationErrors) Errors() []FieldError {
+	if ve == nil {
+		return nil
+	}
+	return slices.Clone(ve.errs)
+}
+
+// Header appends a FieldError for the HTTP request header with the given name and detail to the ValidationErrors.
+func (ve *ValidationErrors) Header(name, detail string) *ValidationErrors {
+	return ve.Add(FieldError{Detail: detail, Header: name})
+}
+
+// Len returns the number of FieldError within the ValidationErrors.
+func (ve *ValidationErrors) Len() int {
+	if ve == nil {
+		return 0
+	}
+	return len(ve.errs)
+}
+
+// Parameter appends a FieldError for the query parameter with the given name and detail to the ValidationErrors.
+func (ve *ValidationErrors) Parameter(name, detail string) *ValidationErrors {
+	return ve.Add(FieldError{Detail: detail, Parameter: name})
+}
+
+// Pointer appends a FieldError for the value within the request body identified by the given JSON Pointer with the
+// detail provided to the ValidationErrors.
+func (ve *ValidationErrors) Pointer(pointer, detail string) *ValidationErrors {
+	return ve.Add(FieldError{Detail: detail, Pointer: pointer})
+}
+
+// FieldErrors returns the FieldError within the extension of the Problem with the ValidationErrorsExtensionKey, if any.
+//
+// The FieldError are returned regardless of whether the Problem was built with ValidationErrors or decoded (e.g. via
+// Problem.UnmarshalJSON, Problem.UnmarshalXML, or ReadResponse). nil is returned if the extension is missing or cannot
+// be converted.
+func (p *Problem) FieldErrors() []FieldError {
+	value, found := p.Extension(ValidationErrorsExtensionKey)
+	if !found {
+		return nil
+	}
+	if m, ok := value.(map[string]any); ok && len(m) == 1 {
+		// Arrays decoded from XML are represented by their array item elements
+		if items, ok := m[xmlArrayItemLocalName]; ok {
+			if _, ok = items.([]any); !ok {
+				items = []any{items}
+			}
+			value = items
+		}
+	}
+	fieldErrs, err := convertValue[[]FieldError](value)
+	if err != nil {
+		return nil
+	}
+	return fieldErrs
+}
+
+// JSONPointer returns a JSON Pointer (RFC 6901) built from the given reference tokens, which are escaped as required.
+// Tokens that are not strings (e.g. array indices) are formatted using fmt.Sprint.
+//
+// For example;
+//
+//	JSONPointer()                         // ""
+//	JSONPointer("profile", "color")       // "/profile/color"
+//	JSONPointer("items", 0, "name")       // "/items/0/name"
+//	JSONPointer("a/b", "m~n")             // "/a~1b/m~0n"
+func JSONPointer(tokens ...any) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		s, ok := token.(string)
+		if !ok {
+			s = fmt.Sprint(token)
+		}
+		sb.WriteByte('/')
+		sb.WriteString(jsonPointerEscaper.Replace(s))
+	}
+	return sb.String()
+}
+
+// jsonPointerEscaper is used to escape reference tokens within a JSON Pointer.
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// buildFieldErrors returns a copy of the given FieldError with any translation keys resolved using the Generator. The
+// translation keys are retained so that they can be resolved again by Generator.Localize.
+func (g *Generator) buildFieldErrors(ctx context.Context, fieldErrs []FieldError) []FieldError {
+	built := make([]FieldError, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		fe.Detail = g.translateOrElse(ctx, fe.DetailKey, fe.Detail)
+		built[i] = fe
+	}
+	return built
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	problemhttp "github.com/jay-babu/go-problem/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPointer(t *testing.T) {
+	assert.Equal(t, "", problem.JSONPointer())
+	assert.Equal(t, "/profile/color", problem.JSONPointer("profile", "color"))
+	assert.Equal(t, "/items/0/name", problem.JSONPointer("items", 0, "name"))
+	assert.Equal(t, "/a~1b/m~0n", problem.JSONPointer("a/b", "m~n"))
+}
+
+func TestValidationErrors(t *testing.T) {
+	gen := &problem.Generator{
+		Translator: func(_ context.Context, key any) string {
+			if key == "validation.color" {
+				return "doit être 'green', 'red' ou 'blue'"
+			}
+			return ""
+		},
+	}
+	var errs problem.ValidationErrors
+	errs.Pointer("/age", "must be a positive integer").
+		Parameter("page", "must be a number").
+		Header("If-Match", "must be an entity tag").
+		Add(problem.FieldError{
+			Code:      "VAL-1",
+			Detail:    "must be 'green', 'red' or 'blue'",
+			DetailKey: "validation.color",
+			Pointer:   problem.JSONPointer("profile", "color"),
+		})
+	require.Equal(t, 4, errs.Len())
+
+	prob := problemhttp.UnprocessableEntityDefinition.NewUsing(gen, problem.WithValidationErrors(&errs))
+	assert.Equal(t, http.StatusUnprocessableEntity, prob.Status)
+
+	data, err := json.Marshal(prob)
+	require.NoError(t, err)
+	var body struct {
+		Errors []map[string]any `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(data, &body))
+	assert.Equal(t, []map[string]any{
+		{"detail": "must be a positive integer", "pointer": "/age"},
+		{"detail": "must be a number", "parameter": "page"},
+		{"detail": "must be an entity tag", "header": "If-Match"},
+		{"code": "VAL-1", "detail": "doit être 'green', 'red' ou 'blue'", "pointer": "/profile/color"},
+	}, body.Errors)
+
+	// The translation key must not leak into the ValidationErrors used
+	assert.Equal(t, "validation.color", errs.Errors()[3].DetailKey)
+
+	var decoded problem.Problem
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	fieldErrs := decoded.FieldErrors()
+	require.Len(t, fieldErrs, 4)
+	assert.Equal(t, "/profile/color", fieldErrs[3].Pointer)
+	assert.Equal(t, problem.Code("VAL-1"), fieldErrs[3].Code)
+	assert.True(t, problem.Match(&decoded, problem.HasErrorAt("/age")))
+	assert.False(t, problem.Match(&decoded, problem.HasErrorAt("/name")))
+}
+
+func TestValidationErrors_XML(t *testing.T) {
+	var errs problem.ValidationErrors
+	errs.Pointer("/age", "must be a positive integer")
+	prob := problemhttp.BadRequestDefinition.New(problem.WithValidationErrors(&errs))
+
+	data, err := xml.Marshal(prob)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<errors><i><detail>must be a positive integer</detail><pointer>/age</pointer></i></errors>")
+
+	var decoded problem.Problem
+	require.NoError(t, xml.Unmarshal(data, &decoded))
+	assert.Equal(t, []problem.FieldError{{Detail: "must be a positive integer", Pointer: "/age"}}, decoded.FieldErrors())
+	assert.True(t, problem.Match(&decoded, problem.HasErrorAt("/age")))
+}
+
+func TestValidationErrors_Empty(t *testing.T) {
+	var errs problem.ValidationErrors
+	prob := problem.New(problem.WithValidationErrors(&errs), problem.WithValidationErrors(nil))
+	assert.Empty(t, prob.Extensions)
+	assert.Nil(t, prob.FieldErrors())
+}
@@ -0,0 +1,264 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem
+
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// xmlArrayItemLocalName is the local name of each element used to represent an item within an array in accordance with
+// RFC 9457 Appendix B.
+const xmlArrayItemLocalName = "i"
+
+var (
+	// xmlMarshalerType is the reflect.Type of xml.Marshaler.
+	xmlMarshalerType = reflect.TypeFor[xml.Marshaler]()
+	// textMarshalerType is the reflect.Type of encoding.TextMarshaler.
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// xmlProblem is used to allow XML data to be unmarshaled into a Problem without having it resolved using the Registry of
+// DefaultGenerator.
+type xmlProblem Problem
+
+// xmlChild is a child element decoded from XML, retaining its local name so that the order and any repetition of
+// elements can be preserved.
+type xmlChild struct {
+	name  string
+	value any
+}
+
+// decodeXMLChildren decodes all tokens up to and including the end element matching the most recently decoded start
+// element, returning the character data and any child elements in the order they were declared.
+//
+// Each child element is decoded recursively into either a string (if it contains no child elements), a slice (if all of
+// its child elements are array items), or a map (otherwise).
+func decodeXMLChildren(d *xml.Decoder) (string, []xmlChild, error) {
+	var (
+		children []xmlChild
+		text     strings.Builder
+	)
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", nil, err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+			v, err := decodeXMLValue(d)
+			if err != nil {
+				return "", nil, err
+			}
+			children = append(children, xmlChild{name: t.Name.Local, value: v})
+		case xml.EndElement:
+			return text.String(), children, nil
+		}
+	}
+}
+
+// decodeXMLProblem decodes all tokens up to and including the end element matching the most recently decoded start
+// element into the Problem provided. See Problem.UnmarshalXML for more information.
+//
+// An error is returned if unable to decode the XML or if any top-level field contains an invalid value.
+func decodeXMLProblem(d *xml.Decoder, p *Problem) error {
+	_, children, err := decodeXMLChildren(d)
+	if err != nil {
+		return err
+	}
+	var (
+		extChildren []xmlChild
+		xp          Problem
+	)
+	for _, c := range children {
+		if c.name == "extensions" {
+			// Tolerate extensions being nested within an element of their own
+			if m, ok := c.value.(map[string]any); ok {
+				if xp.Extensions == nil {
+					xp.Extensions = make(Extensions, len(m))
+				}
+				maps.Copy(xp.Extensions, m)
+			}
+			continue
+		}
+		if _, reserved := reservedExtensions[c.name]; !reserved {
+			extChildren = append(extChildren, c)
+			continue
+		}
+		v, ok := c.value.(string)
+		if !ok {
+			return fmt.Errorf("invalid problem XML element <%s>: unexpected child elements", c.name)
+		}
+		switch c.name {
+		case "code":
+			xp.Code = Code(strings.TrimSpace(v))
+		case "detail":
+			xp.Detail = v
+		case "instance":
+			xp.Instance = strings.TrimSpace(v)
+		case "stack":
+			xp.Stack = v
+		case "status":
+			if xp.Status, err = strconv.Atoi(strings.TrimSpace(v)); err != nil {
+				return fmt.Errorf("invalid problem XML element <status>: %w", err)
+			}
+		case "title":
+			xp.Title = v
+		case "type":
+			xp.Type = strings.TrimSpace(v)
+		case "uuid":
+			xp.UUID = strings.TrimSpace(v)
+		}
+	}
+	if len(extChildren) > 0 {
+		if xp.Extensions == nil {
+			xp.Extensions = make(Extensions, len(extChildren))
+		}
+		maps.Copy(xp.Extensions, groupXMLChildren(extChildren))
+	}
+	*p = xp
+	return nil
+}
+
+// decodeXMLValue decodes all tokens up to and including the end element matching the most recently decoded start
+// element into the most suitable value. See decodeXMLChildren for more information.
+func decodeXMLValue(d *xml.Decoder) (any, error) {
+	text, children, err := decodeXMLChildren(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(children) == 0 {
+		return text, nil
+	}
+	isArray := !slices.ContainsFunc(children, func(c xmlChild) bool {
+		return c.name != xmlArrayItemLocalName
+	})
+	if isArray {
+		items := make([]any, len(children))
+		for i, c := range children {
+			items[i] = c.value
+		}
+		return items, nil
+	}
+	return groupXMLChildren(children), nil
+}
+
+// encodeXMLValue encodes the given value as an element with the given local name.
+//
+// Maps with string keys are encoded with an element per entry, in order of their keys, while slices and arrays are
+// encoded with an array item element per item, in accordance with RFC 9457 Appendix B. All other values are encoded
+// using the xml package.
+func encodeXMLValue(e *xml.Encoder, name string, v any) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() || rv.Type().Implements(xmlMarshalerType) || rv.Type().Implements(textMarshalerType) {
+			break
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || ((rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil()) {
+		return encodeXMLElement(e, start, nil)
+	}
+	if rv.Type().Implements(xmlMarshalerType) || rv.Type().Implements(textMarshalerType) {
+		return e.EncodeElement(rv.Interface(), start)
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key type for XML extension %q: %v", name, rv.Type().Key())
+		}
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		return encodeXMLElement(e, start, func() error {
+			for _, k := range keys {
+				if err := encodeXMLValue(e, k.String(), rv.MapIndex(k).Interface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			// Let the xml package handle byte slices and arrays consistently with other fields
+			return e.EncodeElement(rv.Interface(), start)
+		}
+		return encodeXMLElement(e, start, func() error {
+			for i := 0; i < rv.Len(); i++ {
+				if err := encodeXMLValue(e, xmlArrayItemLocalName, rv.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	default:
+		return e.EncodeElement(rv.Interface(), start)
+	}
+}
+
+// encodeXMLElement encodes the given start element, followed by any content encoded by the function provided, if any,
+// and finally its corresponding end element.
+func encodeXMLElement(e *xml.Encoder, start xml.StartElement, content func() error) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if content != nil {
+		if err := content(); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// groupXMLChildren returns a map containing the values of the given child elements, keyed by their local names, with the
+// values of any repeated elements collected into a slice in the order they were declared.
+func groupXMLChildren(children []xmlChild) map[string]any {
+	counts := make(map[string]int, len(children))
+	for _, c := range children {
+		counts[c.name]++
+	}
+	m := make(map[string]any, len(counts))
+	for _, c := range children {
+		if counts[c.name] == 1 {
+			m[c.name] = c.value
+		} else {
+			items, _ := m[c.name].([]any)
+			m[c.name] = append(items, c.value)
+		}
+	}
+	return m
+}
+
+// UnmarshalXML unmarshals the XML element provided into the Problem without resolving it using any Registry.
+func (xp *xmlProblem) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error {
+	return decodeXMLProblem(d, (*Problem)(xp))
+}
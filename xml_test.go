@@ -0,0 +1,95 @@
+// Copyright (C) 2025 jay-babu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package problem_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/jay-babu/go-problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblem_XMLRoundTrip(t *testing.T) {
+	p := &problem.Problem{
+		Code:   "FOO-400",
+		Detail: "Your current balance is 30, but that costs 50.",
+		Extensions: problem.Extensions{
+			"accounts": []string{"/account/12345", "/account/67890"},
+			"balance":  30,
+			"limits":   map[string]any{"min": 10, "max": 100},
+		},
+		Instance: "/account/12345/msgs/abc",
+		Status:   403,
+		Title:    "You do not have enough credit.",
+		Type:     "https://example.com/probs/out-of-credit",
+	}
+
+	b, err := xml.Marshal(p)
+	require.NoError(t, err)
+	assert.Equal(t, `<problem xmlns="urn:ietf:rfc:9457">`+
+		`<code>FOO-400</code>`+
+		`<detail>Your current balance is 30, but that costs 50.</detail>`+
+		`<accounts><i>/account/12345</i><i>/account/67890</i></accounts>`+
+		`<balance>30</balance>`+
+		`<limits><max>100</max><min>10</min></limits>`+
+		`<instance>/account/12345/msgs/abc</instance>`+
+		`<status>403</status>`+
+		`<title>You do not have enough credit.</title>`+
+		`<type>https://example.com/probs/out-of-credit</type>`+
+		`</problem>`, string(b))
+
+	var actual problem.Problem
+	require.NoError(t, xml.Unmarshal(b, &actual))
+	assert.Equal(t, problem.Problem{
+		Code:   "FOO-400",
+		Detail: "Your current balance is 30, but that costs 50.",
+		Extensions: problem.Extensions{
+			"accounts": []any{"/account/12345", "/account/67890"},
+			"balance":  "30",
+			"limits":   map[string]any{"min": "10", "max": "100"},
+		},
+		Instance: "/account/12345/msgs/abc",
+		Status:   403,
+		Title:    "You do not have enough credit.",
+		Type:     "https://example.com/probs/out-of-credit",
+	}, actual)
+}
+
+func TestProblem_UnmarshalXML_RepeatedElements(t *testing.T) {
+	data := `<problem xmlns="urn:ietf:rfc:9457">
+  <status>400</status>
+  <title>Bad Request</title>
+  <tag>a</tag>
+  <tag>b</tag>
+  <nested><key>x</key><key>y</key></nested>
+</problem>`
+
+	var actual problem.Problem
+	require.NoError(t, xml.Unmarshal([]byte(data), &actual))
+	assert.Equal(t, 400, actual.Status)
+	assert.Equal(t, "Bad Request", actual.Title)
+	assert.Equal(t, problem.Extensions{
+		"nested": map[string]any{"key": []any{"x", "y"}},
+		"tag":    []any{"a", "b"},
+	}, actual.Extensions)
+}
@@ -3,7 +3,7 @@ module github.com/jay-babu/go-problem/zap
 go 1.24
 
 require (
-	github.com/neocotic/go-problem v0.4.0
+	github.com/jay-babu/go-problem v0.4.0
 	go.uber.org/zap v1.27.1
 )
 
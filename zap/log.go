@@ -23,6 +23,7 @@ package problemzap
 import (
 	"context"
 	"log/slog"
+	"strconv"
 
 	"github.com/jay-babu/go-problem"
 	"go.uber.org/zap"
@@ -102,14 +103,24 @@ func NamedField(key string, prob *problem.Problem) zapcore.Field {
 	if prob.Code != "" {
 		fields = append(fields, zap.String("code", string(prob.Code)))
 	}
-	if prob.Detail != "" {
-		fields = append(fields, zap.String("detail", prob.Detail))
+	if logInfo.Detail != "" {
+		fields = append(fields, zap.String("detail", logInfo.Detail))
 	}
-	if err := prob.Unwrap(); err != nil {
-		fields = append(fields, zap.String("error", err.Error()))
+	switch causes := prob.Causes(); len(causes) {
+	case 0:
+	case 1:
+		fields = append(fields, zap.NamedError("error", causes[0]))
+	default:
+		// Each cause is logged in the same way as a single cause, keyed by its index, consistent with
+		// problem.Problem.LogValue
+		errs := make([]zapcore.Field, len(causes))
+		for i, cause := range causes {
+			errs[i] = zap.NamedError(strconv.Itoa(i), cause)
+		}
+		fields = append(fields, zap.Dict("errors", errs...))
 	}
-	if len(prob.Extensions) > 0 {
-		fields = append(fields, mapField("extensions", prob.Extensions))
+	if len(logInfo.Extensions) > 0 {
+		fields = append(fields, mapField("extensions", logInfo.Extensions))
 	}
 	if prob.Instance != "" {
 		fields = append(fields, zap.String("instance", prob.Instance))